@@ -11,6 +11,7 @@ import (
 type addFlags struct {
 	due      string
 	priority bool
+	tags     []string
 }
 
 // helper function to parse flags with error handling
@@ -28,6 +29,11 @@ func getAddFlags(cmd *cobra.Command) (addFlags, error) {
 		return flags, fmt.Errorf("failed to parse --priority flag: %w", err)
 	}
 
+	flags.tags, err = cmd.Flags().GetStringSlice("tag")
+	if err != nil {
+		return flags, fmt.Errorf("failed to parse --tag flag: %w", err)
+	}
+
 	return flags, nil
 }
 
@@ -41,8 +47,11 @@ Tasks have 5 fields:
 - ID: The unique identifier for the task. This is automatically assigned.
 - Title: The task description. This field is mandatory, use quotes for multi-word titles.
 - Due Date: The due date of the task. This field is optional and can be set using the --due flag. (format: YYYY-MM-DD)
-- Complete: Indicates whether a task is open (incomplete) or complete. New tasks are open by default
-- Priority: A task can be normal or high priority. Use the --priority flag to mark it as high.`,
+  You can also give a relative date: today, tomorrow, a weekday such as friday, or a period such as +2w or +1m, where months are calendar months.
+- Status: Where the task is in your workflow, such as todo, doing or done. New tasks are todo by default
+- Priority: A task can be normal or high priority. Use the --priority flag to mark it as high.
+
+Tasks can also be given tags with --tag, such as a sprint or area of work, to group them.`,
 
 	Example: `  tidytask add "Finish Homework"
   > Add Finish Homework to your to-do list
@@ -54,7 +63,10 @@ Tasks have 5 fields:
   > Add "E-Mail boss" to your to-do list and mark task as high priority
 
   tidytask add Finish Project --due 02-01-2006 --priority
-  > Add "Finish Project" to your to-do list with 2nd of January 2006 as the due date and mark task as high priority`,
+  > Add "Finish Project" to your to-do list with 2nd of January 2006 as the due date and mark task as high priority
+
+  tidytask add "Fix login bug" --tag sprint-5 --tag auth
+  > Add "Fix login bug" to your to-do list, tagged sprint-5 and auth`,
 
 	// command logic
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -68,16 +80,29 @@ Tasks have 5 fields:
 			return fmt.Errorf("accepts 1 argument, received %d; use quotes for multi-word input", len(args))
 		}
 
+		// get store
+		store, err := getStore(cmd)
+		if err != nil {
+			return err
+		}
+
 		// get flags
 		flags, err := getAddFlags(cmd)
 		if err != nil {
 			return err
 		}
 
-		// if due date is provided, check it matches format YYYY-MM-DD
+		// if due date is provided, resolve relative dates and check the format is YYYY-MM-DD
 		if flags.due != "" {
-			if err := util.VerifyDate(flags.due); err != nil {
-				return fmt.Errorf("invalid date format. use YYYY-MM-DD")
+			if flags.due, err = util.ParseDate(flags.due); err != nil {
+				return err
+			}
+		}
+
+		// check tags are valid
+		for _, tag := range flags.tags {
+			if err := task.CheckTag(tag); err != nil {
+				return err
 			}
 		}
 
@@ -90,20 +115,26 @@ Tasks have 5 fields:
 			Due:      flags.due,
 			Complete: false,
 			Priority: flags.priority,
+			Tags:     flags.tags,
 		}
 
 		// backup database
-		if err := task.BackupDB(); err != nil {
+		if err := store.Backup(); err != nil {
 			fmt.Printf("Warning: failed to back up database: %v", err)
 		}
 
 		// add task to database
-		if err := task.AddTask(newTask); err != nil {
+		id, err := store.Add(newTask)
+		if err != nil {
 			return fmt.Errorf("failed to add task: %w", err)
 		}
 
-		// exit
-		fmt.Println("Task added")
+		// show the new task's ID and handle, either can be used to refer to it
+		added, err := store.Get(id)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve added task: %w", err)
+		}
+		fmt.Printf("Task added: %d (%s)\n", added.ID, added.Handle)
 		return nil
 	},
 }
@@ -113,8 +144,13 @@ func init() {
 
 	// define flags and add subcommand to root
 
-	addCmd.Flags().StringP("due", "d", "", "Add a due date to task (YYYY--MM-DD)")
+	addCmd.Flags().StringP("due", "d", "", "Add a due date to task (YYYY-MM-DD, or e.g. tomorrow, friday, +1w)")
 	addCmd.Flags().BoolP("priority", "p", false, "Mark task as high priority")
+	addCmd.Flags().StringSlice("tag", nil, "Tag the task, can be repeated or comma separated")
+
+	// suggest due dates and tags
+	_ = addCmd.RegisterFlagCompletionFunc("due", completeDueDate)
+	_ = addCmd.RegisterFlagCompletionFunc("tag", completeTags)
 
 	rootCmd.AddCommand(addCmd)
 }
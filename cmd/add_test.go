@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAdd(t *testing.T) {
+	store := newStore(t)
+
+	out := mustRun(t, store, "add", "Write report", "--due", "2030-01-02", "--priority", "--tag", "work,urgent")
+	assertContains(t, out, "Task added: 1 (")
+
+	got := getTask(t, store, 1)
+	if got.Title != "Write report" || got.Due != "2030-01-02" || !got.Priority || got.Complete {
+		t.Errorf("added task = %+v", got)
+	}
+	if !slices.Equal(got.Tags, []string{"urgent", "work"}) {
+		t.Errorf("tags = %v, want [urgent work]", got.Tags)
+	}
+	assertContains(t, out, got.Handle)
+}
+
+func TestAddErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"no title", []string{"add"}},
+		{"unquoted title", []string{"add", "two", "words"}},
+		{"invalid due date", []string{"add", "Task", "--due", "not-a-date"}},
+		{"invalid tag", []string{"add", "Task", "--tag", "has space"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newStore(t)
+			if _, err := run(t, store, tt.args...); err == nil {
+				t.Fatal("expected an error")
+			}
+			if ids := taskIDs(t, store); len(ids) != 0 {
+				t.Errorf("tasks were added: %v", ids)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/tm-craggs/tidytask/task"
+	"github.com/tm-craggs/tidytask/util"
+	"sort"
+	"strings"
+)
+
+// Sections of the agenda, in the order they are shown
+const (
+	agendaOverdue = iota
+	agendaToday
+	agendaTomorrow
+	agendaThisWeek
+	agendaLater
+	agendaNoDue
+)
+
+// agendaTitles are the headings of the agenda sections
+var agendaTitles = []string{"Overdue", "Today", "Tomorrow", "This week", "Later", "No due date"}
+
+// agendaCmd represents the agenda subcommand
+var agendaCmd = &cobra.Command{
+	Use:   "agenda",
+	Short: "Show open tasks grouped by when they are due",
+	Long: `The 'agenda' command shows your open tasks in sections: Overdue, Today, Tomorrow, This week, Later and
+No due date, so you can see what needs doing at a glance.
+
+This week covers the six days after today, as in the due column of 'list'. Within each section, high priority
+tasks come first and are marked with !.
+
+Only tasks due within 14 days of today are shown, along with overdue tasks and those with no due date. Use
+--days to look further ahead, or less far.`,
+
+	Example: `  tidytask agenda
+  > Show open tasks due in the next 14 days, and those with no due date
+
+  tidytask agenda --days 30
+  > Show open tasks due in the next 30 days`,
+
+	// main command logic
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		// check args
+		if len(args) > 0 {
+			return fmt.Errorf("unexpected arguments: %v; use --help for usage information", args)
+		}
+
+		// get flags
+		days, err := cmd.Flags().GetInt("days")
+		if err != nil {
+			return fmt.Errorf("failed to parse --days flag: %w", err)
+		}
+		if days < 0 {
+			return fmt.Errorf("invalid --days value %d; must not be negative", days)
+		}
+
+		// get store
+		store, err := getStore(cmd)
+		if err != nil {
+			return err
+		}
+
+		// get open tasks
+		tasks, err := store.List(task.Filter{Open: true})
+		if err != nil {
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+		if len(tasks) == 0 {
+			fmt.Println("No open tasks.")
+			return nil
+		}
+
+		// sort tasks by due date, with tasks without a due date last
+		sort.SliceStable(tasks, func(i, j int) bool {
+			a, b := tasks[i].Due, tasks[j].Due
+			if a == "" || b == "" {
+				return a != "" && b == ""
+			}
+			return a < b
+		})
+
+		// put each task in a section, leaving out those due after the horizon
+		horizon := util.Today().AddDate(0, 0, days).Format("2006-01-02")
+		sections := make([]util.AgendaSection, len(agendaTitles))
+		for i, title := range agendaTitles {
+			sections[i].Title = title
+		}
+		hidden := 0
+		for _, t := range tasks {
+			s := agendaSection(t.Due)
+			if s != agendaNoDue && t.Due > horizon {
+				hidden++
+				continue
+			}
+			sections[s].Tasks = append(sections[s].Tasks, t)
+		}
+
+		// put high priority tasks first in each section
+		for _, s := range sections {
+			sort.SliceStable(s.Tasks, func(i, j int) bool {
+				return s.Tasks[i].Priority && !s.Tasks[j].Priority
+			})
+		}
+
+		// print agenda
+		util.PrintAgenda(sections)
+		if hidden > 0 {
+			fmt.Printf("\n%d more %s due after %s; use --days to see them.\n", hidden, pluralTasks(hidden), horizon)
+		}
+
+		// exit
+		return nil
+	},
+}
+
+// agendaSection returns the section for a due date, following the relative due dates shown by list
+func agendaSection(due string) int {
+	deadline := util.FormatDeadline(due)
+	switch {
+	case due == "":
+		return agendaNoDue
+	case strings.HasPrefix(deadline, "Overdue"):
+		return agendaOverdue
+	case deadline == "Today":
+		return agendaToday
+	case deadline == "Tomorrow":
+		return agendaTomorrow
+	case deadline != due:
+		// a weekday name, for dates within the next week
+		return agendaThisWeek
+	default:
+		return agendaLater
+	}
+}
+
+// command initialisation
+func init() {
+
+	// define flags and add subcommand to root
+	agendaCmd.Flags().IntP("days", "d", 14, "Show tasks due up to this many days from today")
+	rootCmd.AddCommand(agendaCmd)
+}
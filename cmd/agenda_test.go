@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tm-craggs/tidytask/task"
+	"github.com/tm-craggs/tidytask/util"
+)
+
+// dueIn returns the date days from today, as stored in a task's due date
+func dueIn(days int) string {
+	return util.Today().AddDate(0, 0, days).Format("2006-01-02")
+}
+
+func TestAgendaSection(t *testing.T) {
+	tests := []struct {
+		due  string
+		want int
+	}{
+		{"", agendaNoDue},
+		{dueIn(-30), agendaOverdue},
+		{dueIn(-1), agendaOverdue},
+		{dueIn(0), agendaToday},
+		{dueIn(1), agendaTomorrow},
+		{dueIn(2), agendaThisWeek},
+		{dueIn(6), agendaThisWeek},
+		{dueIn(7), agendaLater},
+		{dueIn(400), agendaLater},
+	}
+	for _, tt := range tests {
+		if got := agendaSection(tt.due); got != tt.want {
+			t.Errorf("agendaSection(%q) = %s, want %s", tt.due, agendaTitles[got], agendaTitles[tt.want])
+		}
+	}
+}
+
+func TestAgenda(t *testing.T) {
+	store := newStore(t,
+		task.Task{Title: "Late", Due: dueIn(-2)},
+		task.Task{Title: "Today normal", Due: dueIn(0)},
+		task.Task{Title: "Today urgent", Due: dueIn(0), Priority: true},
+		task.Task{Title: "Soon", Due: dueIn(3), Tags: []string{"work"}},
+		task.Task{Title: "Fortnight", Due: dueIn(10), Status: task.StatusDoing},
+		task.Task{Title: "Far off", Due: dueIn(60)},
+		task.Task{Title: "Whenever"},
+		task.Task{Title: "Finished", Due: dueIn(0), Status: task.StatusDone},
+	)
+
+	out := mustRun(t, store, "agenda")
+	assertContains(t, out, "Overdue (1)", "Today (2)", "This week (1)", "Later (1)", "No due date (1)", "#work", "doing",
+		"1 more task due after "+dueIn(14)+"; use --days to see them.")
+	assertNotContains(t, out, "Tomorrow (", "Far off", "Finished")
+
+	// sections are in order, with high priority tasks first
+	order := []string{"Overdue", "Late", "Today (2)", "Today urgent", "Today normal", "This week", "Soon", "Later",
+		"Fortnight", "No due date", "Whenever"}
+	last := -1
+	for _, s := range order {
+		i := strings.Index(out, s)
+		if i < last {
+			t.Errorf("%q is out of order:\n%s", s, out)
+		}
+		last = i
+	}
+
+	// --days moves the horizon
+	out = mustRun(t, store, "agenda", "--days", "90")
+	assertContains(t, out, "Later (2)", "Far off")
+	assertNotContains(t, out, "more task")
+
+	out = mustRun(t, store, "agenda", "--days", "0")
+	assertContains(t, out, "Overdue (1)", "Today (2)", "No due date (1)", "3 more tasks due after "+dueIn(0))
+	assertNotContains(t, out, "Soon")
+}
+
+func TestAgendaEmpty(t *testing.T) {
+	store := newStore(t, task.Task{Title: "Finished", Status: task.StatusDone})
+	out := mustRun(t, store, "agenda")
+	assertContains(t, out, "No open tasks.")
+
+	if _, err := run(t, store, "agenda", "--days", "-1"); err == nil {
+		t.Error("expected an error for negative --days")
+	}
+	if _, err := run(t, store, "agenda", "today"); err == nil {
+		t.Error("expected an error for an argument")
+	}
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/tm-craggs/tidytask/task"
+	"github.com/tm-craggs/tidytask/util"
+	"time"
+)
+
+// create struct that defines the available flags for calendar command
+type calendarFlags struct {
+	month    string
+	week     bool
+	complete bool
+	titles   bool
+}
+
+// helper function to parse flags with error handling
+func getCalendarFlags(cmd *cobra.Command) (calendarFlags, error) {
+	var flags calendarFlags
+	var err error
+
+	if flags.month, err = cmd.Flags().GetString("month"); err != nil {
+		return flags, fmt.Errorf("failed to parse --month flag: %w", err)
+	}
+	if flags.week, err = cmd.Flags().GetBool("week"); err != nil {
+		return flags, fmt.Errorf("failed to parse --week flag: %w", err)
+	}
+	if flags.complete, err = cmd.Flags().GetBool("complete"); err != nil {
+		return flags, fmt.Errorf("failed to parse --complete flag: %w", err)
+	}
+	if flags.titles, err = cmd.Flags().GetBool("titles"); err != nil {
+		return flags, fmt.Errorf("failed to parse --titles flag: %w", err)
+	}
+
+	return flags, nil
+}
+
+// calendarCmd represents the calendar subcommand
+var calendarCmd = &cobra.Command{
+	Use:   "calendar",
+	Short: "Show tasks on a calendar by due date",
+	Long: `The 'calendar' command shows a month as a grid, with the number of open tasks due on each day, so busy
+days stand out. Days with overdue tasks are shown in red, and today is marked.
+
+Use --month to show another month, given as YYYY-MM, or --week to show only this week. The week view lists
+the title of each task, use --titles to list titles in the month view too.
+
+Completed tasks are left out unless --complete is set. Cancelled tasks are never shown.`,
+
+	Example: `  tidytask calendar
+  > Show the number of tasks due each day this month
+
+  tidytask calendar --month 2025-07 --complete
+  > Show open and completed tasks due in July 2025
+
+  tidytask calendar --week
+  > Show the tasks due each day this week`,
+
+	// main command logic
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		// check args
+		if len(args) > 0 {
+			return fmt.Errorf("unexpected arguments: %v; use --help for usage information", args)
+		}
+
+		// get flags
+		flags, err := getCalendarFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if flags.week && flags.month != "" {
+			return fmt.Errorf("cannot use --month with --week")
+		}
+
+		// work out the days to show, and the heading
+		var first, last time.Time
+		var heading string
+		opts := util.CalendarOptions{Titles: flags.titles, TitleWidth: 12}
+		switch {
+		case flags.week:
+			first = util.StartOfWeek(util.Today())
+			last = first.AddDate(0, 0, 6)
+			heading = fmt.Sprintf("Week of %s", first.Format("2 January 2006"))
+			opts.Titles = true
+			opts.TitleWidth = 16
+		default:
+			month := util.Today()
+			if flags.month != "" {
+				if month, err = time.ParseInLocation("2006-01", flags.month, time.Local); err != nil {
+					return fmt.Errorf("invalid month %q; use YYYY-MM", flags.month)
+				}
+			}
+			first = time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.Local)
+			last = first.AddDate(0, 1, -1)
+			heading = first.Format("January 2006")
+		}
+
+		// get store
+		store, err := getStore(cmd)
+		if err != nil {
+			return err
+		}
+
+		// get the tasks due in the calendar
+		tasks, err := store.List(task.Filter{
+			DueFrom: first.Format("2006-01-02"),
+			DueTo:   last.Format("2006-01-02"),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+		var shown []task.Task
+		for _, t := range tasks {
+			if t.Status == task.StatusCancelled || (t.Complete && !flags.complete) {
+				continue
+			}
+			shown = append(shown, t)
+		}
+
+		// print calendar
+		fmt.Println(heading)
+		if err := util.PrintCalendar(first, last, shown, opts); err != nil {
+			return fmt.Errorf("failed to print calendar: %w", err)
+		}
+
+		// exit
+		return nil
+	},
+}
+
+// command initialisation
+func init() {
+
+	// define flags and add subcommand to root
+	calendarCmd.Flags().StringP("month", "m", "", "Month to show, as YYYY-MM")
+	calendarCmd.Flags().BoolP("week", "w", false, "Show this week, with task titles")
+	calendarCmd.Flags().BoolP("complete", "c", false, "Show completed tasks")
+	calendarCmd.Flags().BoolP("titles", "t", false, "Show task titles instead of counts")
+	_ = calendarCmd.RegisterFlagCompletionFunc("month", cobra.NoFileCompletions)
+	rootCmd.AddCommand(calendarCmd)
+}
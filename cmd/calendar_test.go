@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/tm-craggs/tidytask/task"
+	"github.com/tm-craggs/tidytask/util"
+)
+
+func TestCalendarMonth(t *testing.T) {
+	store := newStore(t,
+		task.Task{Title: "Report", Due: "2025-07-14"},
+		task.Task{Title: "Review", Due: "2025-07-14"},
+		task.Task{Title: "Invoice", Due: "2025-07-03", Status: task.StatusDone},
+		task.Task{Title: "Dropped", Due: "2025-07-21", Status: task.StatusCancelled},
+		task.Task{Title: "August", Due: "2025-08-01"},
+	)
+
+	out := mustRun(t, store, "calendar", "--month", "2025-07")
+	assertContains(t, out, "July 2025", "MON", "SUN", "31", "2 due")
+	assertNotContains(t, out, "done", "Report", "Dropped")
+
+	// completed tasks are shown with --complete, cancelled tasks never are
+	out = mustRun(t, store, "calendar", "--month", "2025-07", "--complete")
+	assertContains(t, out, "2 due", "1 done")
+
+	out = mustRun(t, store, "calendar", "--month", "2025-07", "--complete", "--titles")
+	assertContains(t, out, "• Report", "• Review", "✓ Invoice")
+	assertNotContains(t, out, "2 due", "Dropped", "August")
+}
+
+func TestCalendarWeek(t *testing.T) {
+	monday := util.StartOfWeek(util.Today())
+	store := newStore(t,
+		task.Task{Title: "This week", Due: monday.AddDate(0, 0, 2).Format("2006-01-02")},
+		task.Task{Title: "Next week", Due: monday.AddDate(0, 0, 7).Format("2006-01-02")},
+	)
+
+	out := mustRun(t, store, "calendar", "--week")
+	assertContains(t, out, "Week of "+monday.Format("2 January 2006"), "• This week", "today")
+	assertNotContains(t, out, "Next week")
+}
+
+func TestCalendarErrors(t *testing.T) {
+	store := newStore(t)
+	for _, args := range [][]string{
+		{"calendar", "july"},
+		{"calendar", "--month", "2025-13"},
+		{"calendar", "--month", "July"},
+		{"calendar", "--month", "2025-07", "--week"},
+	} {
+		if _, err := run(t, store, args...); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+}
@@ -42,34 +42,59 @@ var completeCmd = &cobra.Command{
 
 You can complete tasks in two ways:
 1. By specifying one or more task IDs directly
-2. Batch completion using the --all flag and optionally applying constraints, such as --priority.
+2. Batch completion of open tasks using the --all flag and optionally applying constraints, such as --priority.
 This limits the scope of the complete batch operation to tasks meeting the given criteria. 
 
-You must only use one method. Supplying task IDs together with the --all flag for batch completion causes an error.`,
+You must only use one method. Supplying task IDs together with the --all flag for batch completion causes an error.
+
+Instead of single IDs you can give ranges such as 3-8, lists such as 1,4,9, 'last' for the most recently
+added task, or selectors such as @overdue, @today, @this-week, @priority or @doing. When ranges or selectors
+are used, the selected tasks are shown and you are asked to confirm first.
+
+A task's handle, such as k3fa, can be used in place of its ID. Handles are shown by 'list --handles' and never
+change, even when IDs do.
+
+Run 'complete' without task IDs in a terminal to choose tasks from a list: type to filter, press space to select
+tasks and enter to confirm.`,
 	Example: `  tidytask complete 1
   > Complete task 1
 
   tidytask complete 1 2 3
   > Complete tasks 1, 2 and 3
 
+  tidytask complete 3-5 @overdue
+  > Complete tasks 3 to 5, and every overdue task
+
   tidytask complete --all
-  > Complete all tasks
+  > Complete all open tasks
 
   tidytask complete --all --priority
-  > Complete all high priority tasks`,
+  > Complete all open high priority tasks`,
 
 	// main command logic
 	RunE: func(cmd *cobra.Command, args []string) error {
 
+		// get store
+		store, err := getStore(cmd)
+		if err != nil {
+			return err
+		}
+
 		// get flags
 		flags, err := getCompleteFlags(cmd)
 		if err != nil {
 			return err
 		}
 
-		// disallow no input
+		// with no input, choose tasks interactively, which fails outside a terminal
 		if len(args) == 0 && !flags.all {
-			return fmt.Errorf("no arguments provided; task ID or --all flag required")
+			errNoArgs := fmt.Errorf("no arguments provided; task ID or --all flag required")
+			if flags.priority || flags.normal {
+				return errNoArgs
+			}
+			if args, err = pickTasks(store, openTask, "complete", true, errNoArgs); err != nil {
+				return err
+			}
 		}
 
 		// disallow mixed usage
@@ -82,11 +107,6 @@ You must only use one method. Supplying task IDs together with the --all flag fo
 			return fmt.Errorf("conflicting flags: cannot use --priority and --normal together")
 		}
 
-		// backup database
-		if err := task.BackupDB(); err != nil {
-			fmt.Printf("Warning: failed to back up database: %v", err)
-		}
-
 		// filter based removal
 		if len(args) == 0 {
 
@@ -95,12 +115,19 @@ You must only use one method. Supplying task IDs together with the --all flag fo
 				return fmt.Errorf("constraint flags require --all")
 			}
 
-			// get tasks
-			tasks, err := task.GetTasks()
+			// get open tasks that comply with filters, so done and cancelled tasks are left alone
+			tasks, err := store.List(task.Filter{Open: true, Priority: flags.priority, Normal: flags.normal})
 			if err != nil {
 				return fmt.Errorf("failed to retrieve tasks: %w", err)
 			}
 
+			// backup database, unless there is nothing to change
+			if len(tasks) > 0 {
+				if err := store.Backup(); err != nil {
+					fmt.Printf("Warning: failed to back up database: %v", err)
+				}
+			}
+
 			// create list of task IDs that have been completed
 			var completeIDs []int
 
@@ -110,16 +137,8 @@ You must only use one method. Supplying task IDs together with the --all flag fo
 			// loop through all tasks
 			for _, t := range tasks {
 
-				// check task complies with filters
-				if flags.priority && !t.Priority {
-					continue
-				}
-				if flags.normal && t.Priority {
-					continue
-				}
-
 				// complete task
-				if err := task.CompleteTask(t.ID); err != nil {
+				if err := store.Complete(t.ID); err != nil {
 					// add error message to hashmap, with error ID as key value
 					failed[t.ID] = err.Error()
 				} else {
@@ -168,27 +187,24 @@ You must only use one method. Supplying task IDs together with the --all flag fo
 		// create list of task IDs that have been completed
 		var completeIDs []int
 
-		// create hashmap that stores task ID as string, and its error message, if task fails
-		failed := make(map[string]string)
-
-		// loop through all args input
-		for _, arg := range args {
+		// resolve IDs, ranges and selectors, keeping those that fail with their error message
+		ids, failed, err := selectTasks(store, args, "complete", false)
+		if err != nil {
+			return err
+		}
 
-			// parse args into int, add args to failed if needed
-			id, err := strconv.Atoi(arg)
-			if err != nil {
-				failed[arg] = "invalid task ID"
-				continue
+		// backup database, unless there is nothing to change
+		if len(ids) > 0 {
+			if err := store.Backup(); err != nil {
+				fmt.Printf("Warning: failed to back up database: %v", err)
 			}
+		}
 
-			// check task exists, add task to failed if task does not exist
-			if err := task.CheckTaskExists(id); err != nil {
-				failed[strconv.Itoa(id)] = err.Error()
-				continue
-			}
+		// loop through selected tasks
+		for _, id := range ids {
 
 			// complete task, adding to failed if needed
-			if err := task.CompleteTask(id); err != nil {
+			if err := store.Complete(id); err != nil {
 				failed[strconv.Itoa(id)] = err.Error()
 			} else {
 				// removal successful, append ID to completed list
@@ -237,7 +253,7 @@ func init() {
 	// define flags and add subcommand to root
 
 	completeCmd.Flags().BoolP("all", "a", false,
-		"Complete all tasks (can be combined with constraints)")
+		"Complete all open tasks (can be combined with constraints)")
 
 	completeCmd.Flags().BoolP("priority", "p", false,
 		"Constrain --all to only complete high priority tasks")
@@ -245,5 +261,8 @@ func init() {
 	completeCmd.Flags().BoolP("normal", "n", false,
 		"Constrain --all to only complete normal priority tasks")
 
+	// suggest open task IDs
+	completeCmd.ValidArgsFunction = completeTaskIDs(openTask)
+
 	rootCmd.AddCommand(completeCmd)
 }
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tm-craggs/tidytask/task"
+)
+
+func TestComplete(t *testing.T) {
+	store := newStore(t, task.Task{Title: "One"}, task.Task{Title: "Two"}, task.Task{Title: "Three"})
+
+	out := mustRun(t, store, "complete", "1", "3")
+	assertContains(t, out, "Completed tasks: 1, 3")
+
+	for id, want := range map[int]bool{1: true, 2: false, 3: true} {
+		got := getTask(t, store, id)
+		if got.Complete != want {
+			t.Errorf("task %d complete = %v, want %v", id, got.Complete, want)
+		}
+		if want && (got.Status != task.StatusDone || !got.CompleteDate.Valid) {
+			t.Errorf("task %d = %+v, want done with a completion date", id, got)
+		}
+	}
+}
+
+func TestCompleteAll(t *testing.T) {
+	store := newStore(t, task.Task{Title: "Normal"}, task.Task{Title: "Urgent", Priority: true})
+
+	out := mustRun(t, store, "complete", "--all", "--priority")
+	assertContains(t, out, "Completed task: 2")
+	if getTask(t, store, 1).Complete || !getTask(t, store, 2).Complete {
+		t.Error("only the high priority task should be complete")
+	}
+}
+
+func TestCompleteAllSkipsClosedTasks(t *testing.T) {
+	store := newStore(t,
+		task.Task{Title: "Open"},
+		task.Task{Title: "Cancelled", Status: task.StatusCancelled},
+		task.Task{Title: "Done", Status: task.StatusDone},
+	)
+	done := getTask(t, store, 3)
+
+	out := mustRun(t, store, "complete", "--all")
+	assertContains(t, out, "Completed task: 1")
+	if got := getTask(t, store, 2); got.Status != task.StatusCancelled || got.Complete {
+		t.Errorf("cancelled task changed to %+v", got)
+	}
+	if got := getTask(t, store, 3); !got.UpdatedAt.Equal(done.UpdatedAt) || got.CompleteDate != done.CompleteDate {
+		t.Errorf("done task changed from %+v to %+v", done, got)
+	}
+}
+
+func TestCompleteMissingTask(t *testing.T) {
+	store := newStore(t, task.Task{Title: "One"})
+
+	out, err := run(t, store, "complete", "1", "9")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertContains(t, out, "Failed to complete tasks:", "9: task with ID 9 does not exist", "Completed task: 1")
+
+	if _, err := run(t, store, "complete", "9"); err == nil {
+		t.Error("expected an error when no task is completed")
+	}
+}
+
+func TestCompleteWithoutArgs(t *testing.T) {
+	store := newStore(t, task.Task{Title: "One"})
+
+	// outside a terminal there is no picker, so task IDs are required
+	if _, err := run(t, store, "complete"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if getTask(t, store, 1).Complete {
+		t.Error("task was completed")
+	}
+}
+
+func TestCompleteByHandle(t *testing.T) {
+	store := newStore(t, task.Task{Title: "One"}, task.Task{Title: "Two"})
+	two := getTask(t, store, 2)
+
+	// handles and UUIDs are accepted in place of IDs, in any case
+	mustRun(t, store, "complete", strings.ToUpper(two.Handle))
+	if !getTask(t, store, 2).Complete {
+		t.Error("complete by handle did not complete task 2")
+	}
+	mustRun(t, store, "reopen", two.UUID)
+	if getTask(t, store, 2).Complete {
+		t.Error("reopen by UUID did not reopen task 2")
+	}
+	if getTask(t, store, 1).Complete {
+		t.Error("task 1 should be unchanged")
+	}
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/tm-craggs/tidytask/task"
+	"github.com/tm-craggs/tidytask/util"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// completionCmd represents the completion command
+var completionCmd = &cobra.Command{
+	Use:                   "completion [bash|zsh|fish|powershell]",
+	DisableFlagsInUseLine: true,
+	Short:                 "Generate a shell completion script",
+	Long: `The 'completion' command prints a script that lets your shell complete TidyTask commands, flags and task IDs.
+
+Task IDs are suggested with their titles, for example only open tasks are suggested for 'complete',
+and only closed tasks for 'reopen'. Due dates, statuses, tags and view names are completed too.
+
+To load completions:
+
+Bash:
+  $ source <(tidytask completion bash)
+  To load completions for every session, on Linux:
+  $ tidytask completion bash > /etc/bash_completion.d/tidytask
+
+Zsh:
+  $ tidytask completion zsh > "${fpath[1]}/_tidytask"
+  You may need to start a new shell, and have "autoload -U compinit; compinit" in your ~/.zshrc.
+
+Fish:
+  $ tidytask completion fish > ~/.config/fish/completions/tidytask.fish
+
+PowerShell:
+  PS> tidytask completion powershell | Out-String | Invoke-Expression
+  To load completions for every session, add the output to your PowerShell profile.`,
+
+	Example: `  tidytask completion bash > /etc/bash_completion.d/tidytask
+  > Install completions for bash`,
+
+	ValidArgs:   []string{"bash", "zsh", "fish", "powershell"},
+	Annotations: map[string]string{annotationNoDB: "true"},
+
+	// command logic
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		// check args
+		if len(args) != 1 {
+			return fmt.Errorf("accepts 1 argument, received %d; shell required", len(args))
+		}
+
+		// write the script for the chosen shell
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			return rootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			return rootCmd.GenFishCompletion(os.Stdout, true)
+		case "powershell":
+			return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+		default:
+			return fmt.Errorf("unsupported shell %q; use bash, zsh, fish or powershell", args[0])
+		}
+	},
+}
+
+// completeTaskIDs returns a completion function suggesting the IDs of tasks that match, with their titles.
+// IDs already on the command line are not suggested again, and none are suggested when --all is set
+func completeTaskIDs(match func(t task.Task) bool) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+
+		// --all takes no IDs
+		if all, err := cmd.Flags().GetBool("all"); err == nil && all {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		// get store, suggesting nothing if the database cannot be opened
+		store, err := getStore(cmd)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		tasks, err := store.List(task.Filter{})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		var completions []cobra.Completion
+		for _, t := range tasks {
+			id := strconv.Itoa(t.ID)
+			if !match(t) || slices.Contains(args, id) || !strings.HasPrefix(id, toComplete) {
+				continue
+			}
+			completions = append(completions, cobra.CompletionWithDesc(id, t.Title))
+		}
+
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// openTask, closedTask and anyTask select which tasks are suggested for completion
+func openTask(t task.Task) bool   { return !t.Closed() }
+func closedTask(t task.Task) bool { return t.Closed() }
+func anyTask(t task.Task) bool    { return true }
+
+// completeStatusArgs suggests a task ID, then a status, for the status command
+func completeStatusArgs(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return completeTaskIDs(anyTask)(cmd, args, toComplete)
+	case 1:
+		return completeStatus(cmd, args, toComplete)
+	default:
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeDueDate suggests relative due dates for flags that set a due date, such as add --due
+func completeDueDate(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	completions := slices.Clone(util.DateKeywords)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		completions = append(completions, strings.ToLower(d.String()))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeDueRange suggests relative dates for flags that filter by due date, such as list --due
+func completeDueRange(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	return util.DueKeywords, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeStatus suggests the built-in and user-defined statuses
+func completeStatus(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	return knownStatuses(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeView suggests the name of a saved view to run, with the command it runs
+func completeView(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeViews(cmd, args, toComplete)
+}
+
+// completeTags suggests the tags already used on tasks
+func completeTags(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	store, err := getStore(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	tasks, err := store.List(task.Filter{})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var tags []string
+	for _, t := range tasks {
+		tags = append(tags, t.Tags...)
+	}
+	return task.NormaliseTags(tags), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeSelectors suggests @ selectors, including statuses and tags in use
+func completeSelectors(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	completions := []cobra.Completion{"@open", "@closed", "@priority", "@normal"}
+	for _, k := range util.DueKeywords {
+		completions = append(completions, "@"+k)
+	}
+	for _, s := range knownStatuses() {
+		completions = append(completions, "@"+s)
+	}
+	tags, _ := completeTags(cmd, args, toComplete)
+	for _, tag := range tags {
+		completions = append(completions, "@tag:"+tag)
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeViews suggests the names of saved views, with the commands they run
+func completeViews(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	var completions []cobra.Completion
+	for _, v := range cfg.Views {
+		if !slices.Contains(args, v.Name) {
+			completions = append(completions, cobra.CompletionWithDesc(v.Name, "tidytask "+formatArgs(v.Args)))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// command initialisation
+func init() {
+
+	// add subcommand to root
+	rootCmd.AddCommand(completionCmd)
+}
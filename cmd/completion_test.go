@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/tm-craggs/tidytask/task"
+)
+
+// complete runs cobra's hidden __complete command for args, returning the suggestions without their directive
+func complete(t *testing.T, store task.Store, args ...string) []string {
+	t.Helper()
+	out := mustRun(t, store, append([]string{"__complete"}, args...)...)
+
+	var completions []string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if line == "" || strings.HasPrefix(line, ":") || strings.HasPrefix(line, "Completion ended") {
+			continue
+		}
+		completions = append(completions, line)
+	}
+	return completions
+}
+
+func TestCompleteTaskIDs(t *testing.T) {
+	store := newStore(t,
+		task.Task{Title: "Open"},
+		task.Task{Title: "Finished", Status: task.StatusDone},
+		task.Task{Title: "Also open"},
+	)
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"complete", ""}, []string{"1\tOpen", "3\tAlso open"}},
+		{[]string{"reopen", ""}, []string{"2\tFinished"}},
+		{[]string{"remove", ""}, []string{"1\tOpen", "3\tAlso open", "2\tFinished"}},
+		{[]string{"complete", "3"}, []string{"3\tAlso open"}},
+		{[]string{"complete", "1", ""}, []string{"3\tAlso open"}},
+		{[]string{"complete", "--all", ""}, nil},
+		{[]string{"status", "1", ""}, []string{"todo", "doing", "blocked", "done", "cancelled"}},
+	}
+	for _, tt := range tests {
+		if got := complete(t, store, tt.args...); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("complete %q = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestCompleteFlags(t *testing.T) {
+	store := newStore(t, task.Task{Title: "Tagged", Tags: []string{"work", "home"}}, task.Task{Title: "Also", Tags: []string{"work"}})
+
+	if got, want := complete(t, store, "add", "--tag", ""), []string{"home", "work"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("tags = %q, want %q", got, want)
+	}
+
+	due := complete(t, store, "add", "--due", "")
+	for _, want := range []string{"today", "tomorrow", "+1w", "monday"} {
+		if !slices.Contains(due, want) {
+			t.Errorf("due dates %q do not include %s", due, want)
+		}
+	}
+
+	where := complete(t, store, "edit", "--where", "")
+	for _, want := range []string{"@open", "@overdue", "@doing", "@tag:home", "@tag:work"} {
+		if !slices.Contains(where, want) {
+			t.Errorf("selectors %q do not include %s", where, want)
+		}
+	}
+
+	if got := complete(t, store, "list", "--due", ""); !slices.Contains(got, "this-week") || slices.Contains(got, "+1w") {
+		t.Errorf("due ranges = %q", got)
+	}
+}
+
+func TestCompleteViews(t *testing.T) {
+	store := newStore(t)
+	mustRun(t, store, "view", "save", "today", "--", "list", "--due", "today")
+	mustRun(t, store, "view", "save", "urgent", "--", "list", "--priority")
+
+	want := []string{"today\ttidytask list --due today", "urgent\ttidytask list --priority"}
+	if got := complete(t, store, "view", "delete", ""); !reflect.DeepEqual(got, want) {
+		t.Errorf("views = %q, want %q", got, want)
+	}
+
+	// views already given are not suggested again
+	if got := complete(t, store, "view", "delete", "today", ""); !reflect.DeepEqual(got, want[1:]) {
+		t.Errorf("views after today = %q, want %q", got, want[1:])
+	}
+}
+
+func TestCompletionScript(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		if out := mustRun(t, newStore(t), "completion", shell); !strings.Contains(out, "tidytask") {
+			t.Errorf("%s script does not mention tidytask", shell)
+		}
+	}
+	if _, err := run(t, newStore(t), "completion", "tcsh"); err == nil {
+		t.Error("expected an error for an unsupported shell")
+	}
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/tm-craggs/tidytask/util"
+	"golang.org/x/term"
+	"os"
+	"strconv"
+)
+
+// envAssumeYes is the environment variable that answers yes to every confirmation, like --yes
+const envAssumeYes = "TIDYTASK_ASSUME_YES"
+
+var (
+	// assumeYes answers yes to every confirmation, set by --yes or TIDYTASK_ASSUME_YES
+	assumeYes bool
+
+	// noInput stops commands from prompting or opening interactive pickers, set by --no-input
+	noInput bool
+)
+
+// readInputFlags sets assumeYes and noInput from the global flags and the environment
+func readInputFlags(cmd *cobra.Command) error {
+	var err error
+	if assumeYes, err = cmd.Flags().GetBool("yes"); err != nil {
+		return fmt.Errorf("failed to parse --yes flag: %w", err)
+	}
+	if noInput, err = cmd.Flags().GetBool("no-input"); err != nil {
+		return fmt.Errorf("failed to parse --no-input flag: %w", err)
+	}
+
+	// the environment can only turn --yes on
+	if env := os.Getenv(envAssumeYes); env != "" && !assumeYes {
+		if assumeYes, err = strconv.ParseBool(env); err != nil {
+			return fmt.Errorf("invalid %s value %q; use true or false", envAssumeYes, env)
+		}
+	}
+
+	return nil
+}
+
+// interactive reports whether the user can be asked for input, stdin must be a terminal and --no-input not set
+func interactive() bool {
+	return !noInput && term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// confirm asks the user to confirm an action, returning an error if they do not.
+// with --yes, or in a dry run where nothing is saved, it succeeds without asking. when the user cannot answer
+// it refuses without asking, so scripts fail instead of waiting for input
+func confirm(prompt string) error {
+	if assumeYes || dryRun {
+		return nil
+	}
+	if noInput {
+		return fmt.Errorf("cannot ask %q with --no-input; use --yes to confirm", prompt)
+	}
+	if !interactive() {
+		return fmt.Errorf("cannot ask %q as stdin is not a terminal; use --yes to confirm", prompt)
+	}
+	if !util.ConfirmAction(os.Stdin, os.Stdout, prompt) {
+		return fmt.Errorf("aborted by user")
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/tm-craggs/tidytask/task"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     string
+		args    []string
+		removed bool
+		err     string
+	}{
+		{"not a terminal", "", nil, false, "stdin is not a terminal; use --yes to confirm"},
+		{"no input", "", []string{"--no-input"}, false, "with --no-input; use --yes to confirm"},
+		{"yes", "", []string{"--yes"}, true, ""},
+		{"short yes", "", []string{"-y"}, true, ""},
+		{"yes and no input", "", []string{"--yes", "--no-input"}, true, ""},
+		{"environment", "true", nil, true, ""},
+		{"environment 1", "1", nil, true, ""},
+		{"environment false", "false", nil, false, "stdin is not a terminal"},
+		{"environment invalid", "sure", nil, false, "invalid TIDYTASK_ASSUME_YES value"},
+		{"environment with --yes", "false", []string{"--yes"}, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(envAssumeYes, tt.env)
+			store := newStore(t, task.Task{Title: "One"}, task.Task{Title: "Two"})
+
+			out, err := run(t, store, append([]string{"remove", "1"}, tt.args...)...)
+			if tt.err == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.err != "" {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				assertContains(t, err.Error(), tt.err)
+			}
+
+			want := []int{1, 2}
+			if tt.removed {
+				want = []int{2}
+				assertContains(t, out, "Removed task: 1")
+			}
+			if ids := taskIDs(t, store); !slices.Equal(ids, want) {
+				t.Errorf("task IDs = %v, want %v", ids, want)
+			}
+		})
+	}
+}
+
+func TestConfirmDryRun(t *testing.T) {
+	store := newStore(t, task.Task{Title: "One"})
+
+	// nothing is saved in a dry run, so there is nothing to confirm
+	mustRun(t, store, "remove", "1", "--dry-run")
+	if ids := taskIDs(t, store); !slices.Equal(ids, []int{1}) {
+		t.Errorf("task IDs = %v, want [1]", ids)
+	}
+}
+
+func TestNoInputSkipsPicker(t *testing.T) {
+	store := newStore(t, task.Task{Title: "One"})
+
+	// without IDs, complete would open a picker in a terminal, with --no-input it fails instead
+	if _, err := run(t, store, "complete", "--no-input"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if getTask(t, store, 1).Complete {
+		t.Error("task was completed")
+	}
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/tm-craggs/tidytask/task"
+	"github.com/tm-craggs/tidytask/util"
+)
+
+var (
+	// dryRun plans changes without saving them, set by --dry-run
+	dryRun bool
+
+	// dryRunStore holds the planned changes during a dry run, it is nil otherwise
+	dryRunStore *task.DryRunStore
+)
+
+// startDryRun hands cmd a DryRunStore copy of store when --dry-run is set, so changes are planned but not saved
+func startDryRun(cmd *cobra.Command, store task.Store) error {
+	if !dryRun {
+		return nil
+	}
+
+	d, err := task.NewDryRunStore(store)
+	if err != nil {
+		return fmt.Errorf("failed to start dry run: %w", err)
+	}
+	dryRunStore = d
+	cmd.SetContext(task.NewContext(cmd.Context(), d))
+
+	fmt.Println("Dry run: no changes will be saved.")
+	return nil
+}
+
+// printDryRun shows the changes planned during a dry run
+func printDryRun() error {
+	if dryRunStore == nil {
+		return nil
+	}
+
+	fmt.Println()
+	switch {
+	case dryRunStore.ResetPlanned():
+		fmt.Println("Dry run: would delete all tasks and the backup.")
+		return nil
+	case dryRunStore.RestorePlanned():
+		fmt.Println("Dry run: would restore the most recent backup, undoing the last change.")
+		return nil
+	}
+
+	changes := dryRunStore.Changes()
+	if len(changes) == 0 {
+		fmt.Println("Dry run: no tasks would change.")
+		return nil
+	}
+
+	// sort the changes by kind
+	var added, removed, changed []task.Task
+	var details [][]string
+	for _, c := range changes {
+		switch {
+		case c.Before == nil:
+			added = append(added, *c.After)
+		case c.After == nil:
+			removed = append(removed, *c.Before)
+		default:
+			changed = append(changed, *c.After)
+			details = append(details, describeChanges(*c.Before, *c.After))
+		}
+	}
+
+	// show each kind of change through the usual table, with the changed fields of each task
+	for _, group := range []struct {
+		label string
+		tasks []task.Task
+	}{
+		{"Dry run: would add", added},
+		{"Dry run: would remove", removed},
+		{"Dry run: would change", changed},
+	} {
+		if len(group.tasks) == 0 {
+			continue
+		}
+		fmt.Printf("%s %d %s:\n", group.label, len(group.tasks), pluralTasks(len(group.tasks)))
+		if err := util.PrintTasks(group.tasks, util.PrintOptions{}); err != nil {
+			return fmt.Errorf("failed to print tasks: %w", err)
+		}
+	}
+	for i, t := range changed {
+		fmt.Printf("Task %d:\n", t.ID)
+		for _, line := range details[i] {
+			fmt.Printf("  %s\n", line)
+		}
+	}
+
+	return nil
+}
+
+// pluralTasks returns "task" for one task, and "tasks" otherwise
+func pluralTasks(n int) string {
+	if n == 1 {
+		return "task"
+	}
+	return "tasks"
+}
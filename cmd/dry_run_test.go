@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tm-craggs/tidytask/task"
+)
+
+func TestDryRun(t *testing.T) {
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"add", "Three"}, []string{"Dry run: would add 1 task:", "Three"}},
+		{[]string{"complete", "1"}, []string{"Dry run: would change 1 task:", "Task 1:", "status:   todo → done"}},
+		{[]string{"start", "1", "2"}, []string{"Dry run: would change 2 tasks:", "Task 2:", "status:   todo → doing"}},
+		{[]string{"edit", "2", "--title", "Deux"}, []string{"Task 2:", `title:    "Two" → "Deux"`}},
+		{[]string{"edit", "--all", "--add-tag", "q3"}, []string{"Dry run: would change 2 tasks:", "tags:     none → q3"}},
+		{[]string{"remove", "1"}, []string{"Dry run: would remove 1 task:", "One"}},
+		{[]string{"remove", "1", "--yes"}, []string{"Dry run: would remove 1 task:"}},
+		{[]string{"renumber"}, []string{"Dry run: no tasks would change."}},
+		{[]string{"undo"}, []string{"Dry run: would restore the most recent backup, undoing the last change."}},
+		{[]string{"reset"}, []string{"Dry run: would delete all tasks and the backup."}},
+	}
+	for _, tt := range tests {
+		store := newStore(t, task.Task{Title: "One"}, task.Task{Title: "Two"})
+		before, err := store.List(task.Filter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out := mustRun(t, store, append(tt.args, "--dry-run")...)
+		assertContains(t, out, append([]string{"Dry run: no changes will be saved."}, tt.want...)...)
+
+		// nothing is saved, and no backup is taken
+		after, err := store.List(task.Filter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(after, before) {
+			t.Errorf("tidytask %v --dry-run changed the tasks to %+v", tt.args, after)
+		}
+		if err := store.Restore(); err == nil {
+			t.Errorf("tidytask %v --dry-run took a backup", tt.args)
+		}
+	}
+}
+
+func TestDryRunErrors(t *testing.T) {
+	store := newStore(t, task.Task{Title: "One"})
+
+	// commands fail in a dry run as they would otherwise
+	if _, err := run(t, store, "complete", "9", "--dry-run"); err == nil {
+		t.Error("expected an error for a missing task")
+	}
+	if _, err := run(t, store, "edit", "1", "--due", "someday", "--dry-run"); err == nil {
+		t.Error("expected an error for an invalid date")
+	}
+
+	// commands that only read tasks run as usual
+	out := mustRun(t, store, "list", "--dry-run")
+	assertContains(t, out, "One", "Dry run: no tasks would change.")
+}
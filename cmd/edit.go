@@ -3,19 +3,25 @@ package cmd
 import (
 	"fmt"
 	"github.com/spf13/cobra"
+	"github.com/tm-craggs/tidytask/selector"
 	"github.com/tm-craggs/tidytask/task"
 	"github.com/tm-craggs/tidytask/util"
-	"strconv"
+	"slices"
 )
 
 // create struct that defines the available flags for edit command
 type editFlags struct {
 	title           string
 	due             string
-	priority        bool
+	priority        string
+	addTags         []string
+	removeTags      []string
 	titleChanged    bool
 	dueChanged      bool
 	priorityChanged bool
+	editor          bool
+	all             bool
+	where           []string
 }
 
 // helper function to parse flags with error handling
@@ -33,11 +39,36 @@ func getEditFlags(cmd *cobra.Command) (editFlags, error) {
 		return flags, fmt.Errorf("failed to parse --due flag: %w", err)
 	}
 
-	flags.priority, err = cmd.Flags().GetBool("priority")
+	flags.priority, err = cmd.Flags().GetString("priority")
 	if err != nil {
 		return flags, fmt.Errorf("failed to parse --priority flag: %w", err)
 	}
 
+	flags.addTags, err = cmd.Flags().GetStringSlice("add-tag")
+	if err != nil {
+		return flags, fmt.Errorf("failed to parse --add-tag flag: %w", err)
+	}
+
+	flags.removeTags, err = cmd.Flags().GetStringSlice("remove-tag")
+	if err != nil {
+		return flags, fmt.Errorf("failed to parse --remove-tag flag: %w", err)
+	}
+
+	flags.all, err = cmd.Flags().GetBool("all")
+	if err != nil {
+		return flags, fmt.Errorf("failed to parse --all flag: %w", err)
+	}
+
+	flags.where, err = cmd.Flags().GetStringArray("where")
+	if err != nil {
+		return flags, fmt.Errorf("failed to parse --where flag: %w", err)
+	}
+
+	flags.editor, err = cmd.Flags().GetBool("editor")
+	if err != nil {
+		return flags, fmt.Errorf("failed to parse --editor flag: %w", err)
+	}
+
 	flags.titleChanged = cmd.Flags().Changed("title")
 	flags.dueChanged = cmd.Flags().Changed("due")
 	flags.priorityChanged = cmd.Flags().Changed("priority")
@@ -45,13 +76,100 @@ func getEditFlags(cmd *cobra.Command) (editFlags, error) {
 	return flags, nil
 }
 
+// priorityToggle is the value of --priority when it is given without a value
+const priorityToggle = "toggle"
+
+// takePriorityArg handles --priority followed by high or normal as a separate word. pflag only reads values
+// of flags with a default, like --priority, when joined with =, so the value is left among the args
+func takePriorityArg(flags *editFlags, args []string) []string {
+	if !flags.priorityChanged || flags.priority != priorityToggle {
+		return args
+	}
+	for i, arg := range args {
+		if arg == "high" || arg == "normal" {
+			flags.priority = arg
+			return append(args[:i:i], args[i+1:]...)
+		}
+	}
+	return args
+}
+
+// checkEditFlags validates the new field values, resolving relative due dates
+func checkEditFlags(flags *editFlags) error {
+	switch flags.priority {
+	case "", priorityToggle, "high", "normal":
+	default:
+		return fmt.Errorf("invalid priority %q; use high or normal", flags.priority)
+	}
+
+	if flags.titleChanged && flags.title == "" {
+		return fmt.Errorf("title cannot be empty")
+	}
+
+	if flags.dueChanged {
+		due, err := util.ParseDate(flags.due)
+		if err != nil {
+			return fmt.Errorf("invalid due date: %w", err)
+		}
+		flags.due = due
+	}
+
+	for _, tag := range append(slices.Clone(flags.addTags), flags.removeTags...) {
+		if err := task.CheckTag(tag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyEdit returns t with the changes given by flags
+func applyEdit(t task.Task, flags editFlags) task.Task {
+
+	// update task title if title flagged
+	if flags.titleChanged {
+		t.Title = flags.title
+	}
+
+	// set or toggle task priority if priority flagged
+	switch flags.priority {
+	case priorityToggle:
+		t.Priority = !t.Priority
+	case "high":
+		t.Priority = true
+	case "normal":
+		t.Priority = false
+	}
+
+	// update due date if due flagged
+	if flags.dueChanged {
+		t.Due = flags.due
+	}
+
+	// add and remove tags
+	tags := append(slices.Clone(t.Tags), flags.addTags...)
+	tags = slices.DeleteFunc(tags, func(tag string) bool {
+		return slices.Contains(flags.removeTags, tag)
+	})
+	t.Tags = task.NormaliseTags(tags)
+
+	return t
+}
+
 // editCmd represents the edit command
 var editCmd = &cobra.Command{
 	Use:   "edit [ID] [flags]",
 	Short: "Edit details of an existing task",
 	Long: `The 'edit' command allows you to update details of an existing task.
 
-Specify the task ID and pass flags for the the details you wish to change.`,
+Specify the task ID or handle and pass flags for the the details you wish to change.
+You can also choose the task with 'last', for the most recently added task, or a selector such as @doing,
+as long as it selects exactly one task. Without a task ID, in a terminal, you can choose the task from a list.
+
+Use --editor to edit tasks in your text editor, set by $VISUAL or $EDITOR. Each task is shown as a block of
+fields: title, due, priority, status and tags. After you save and close the editor, the changes are checked and
+shown for confirmation, then applied together. Nothing is changed if the file is unchanged or invalid.
+With --editor, you can give several task IDs or selectors, or none to edit every open task.`,
 
 	Example: `  tidytask edit 1 --title "Buy Groceries"
 	Change the title of task 1 to "Buy Groceries"
@@ -60,68 +178,117 @@ Specify the task ID and pass flags for the the details you wish to change.`,
 	Change the due date of task 3 to 2nd of January 2006, and toggle the priority status
 
   tidytask edit 5 --title "Clean Room" --due 02-01-2006
-	Change the title of task 5 to Clean Room and change the due date to 2nd of January 2006`,
+	Change the title of task 5 to Clean Room and change the due date to 2nd of January 2006
+
+  tidytask edit 4 --editor
+	Edit task 4 in your text editor
+
+  tidytask edit @overdue --editor
+	Edit every overdue task in your text editor`,
 
 	// main command logic
 	RunE: func(cmd *cobra.Command, args []string) error {
 
-		// check args
-		if len(args) == 0 {
-			return fmt.Errorf("no arguments provided; task ID required")
+		// get flags
+		flags, err := getEditFlags(cmd)
+		if err != nil {
+			return err
 		}
 
+		// allow the priority to be given as a separate word, as in --priority high
+		args = takePriorityArg(&flags, args)
+
+		// check the new field values
+		if err := checkEditFlags(&flags); err != nil {
+			return err
+		}
+
+		// --where chooses tasks for --all
+		if len(flags.where) > 0 && !flags.all {
+			return fmt.Errorf("--where requires --all")
+		}
+
+		// edit selected tasks, or all open tasks, in a text editor
+		if flags.editor {
+			if flags.all || flags.titleChanged || flags.dueChanged || flags.priorityChanged ||
+				len(flags.addTags) > 0 || len(flags.removeTags) > 0 {
+				return fmt.Errorf("conflicting flags: cannot use --editor with --all or field flags")
+			}
+
+			store, err := getStore(cmd)
+			if err != nil {
+				return err
+			}
+			return editInEditor(store, args)
+		}
+
+		// apply the same changes to every task matching --where
+		if flags.all {
+			if len(args) > 0 {
+				return fmt.Errorf("cannot use task IDs and --all together; use --where to choose tasks")
+			}
+
+			store, err := getStore(cmd)
+			if err != nil {
+				return err
+			}
+			return editAll(store, flags)
+		}
+
+		// check args
 		if len(args) > 1 {
 			return fmt.Errorf("accepts 1 argument, received %d; use quotes for multi-word input", len(args))
 		}
 
-		// convert task ID to int
-		id, err := strconv.Atoi(args[0])
+		// get store
+		store, err := getStore(cmd)
 		if err != nil {
-			return fmt.Errorf("invalid task ID %w", err)
+			return err
 		}
 
-		// check task exists
-		if err := task.CheckTaskExists(id); err != nil {
-			return fmt.Errorf("task does not exist %w", err)
+		// with no task given, choose one interactively, which fails outside a terminal
+		if len(args) == 0 {
+			errNoArgs := fmt.Errorf("no arguments provided; task ID or --all flag required")
+			if args, err = pickTasks(store, anyTask, "edit", false, errNoArgs); err != nil {
+				return err
+			}
 		}
 
-		// get flags
-		flags, err := getEditFlags(cmd)
+		// resolve the task ID or selector, which must choose exactly one task
+		result, err := selector.Resolve(store, args, knownStatuses())
 		if err != nil {
 			return err
 		}
-
-		// backup database
-		if err := task.BackupDB(); err != nil {
-			fmt.Printf("Warning: failed to back up database: %v", err)
+		for sel, reason := range result.Failed {
+			return fmt.Errorf("invalid task %s: %s", sel, reason)
 		}
-
-		if !util.ConfirmAction("Confirm edit?") {
-			return fmt.Errorf("aborted by user")
+		if len(result.Tasks) != 1 {
+			return fmt.Errorf("%s selects %d tasks; edit changes one task at a time", args[0], len(result.Tasks))
 		}
+		t := result.Tasks[0]
 
-		// update task title if title flagged
-		if flags.titleChanged {
-			if err := task.SetTitle(id, flags.title); err != nil {
-				return fmt.Errorf("failed to update title: %w", err)
+		// show the task chosen by a selector, as it may not be the one expected
+		if !selector.IsPlain(args) {
+			if err := util.PrintTasks(result.Tasks, util.PrintOptions{}); err != nil {
+				return fmt.Errorf("failed to print tasks: %w", err)
 			}
 		}
 
-		// toggle task priority if priority flagged
-		if flags.priorityChanged {
-			if err := task.TogglePriority(id); err != nil {
-				return fmt.Errorf("failed to toggle priority: %w", err)
-			}
+		if err := confirm("Confirm edit?"); err != nil {
+			return err
 		}
 
-		// update due date if due flagged
-		if flags.dueChanged {
-			if err := util.VerifyDate(flags.due); err != nil {
-				return fmt.Errorf("invalid due date: %w", err)
-			}
-			if err := task.SetDue(id, flags.due); err != nil {
-				return fmt.Errorf("failed to update due date: %w", err)
-			}
+		// backup database
+		if err := store.Backup(); err != nil {
+			fmt.Printf("Warning: failed to back up database: %v", err)
+		}
+
+		// update task fields that were flagged
+		t = applyEdit(t, flags)
+
+		// save changes
+		if err := store.Update(t); err != nil {
+			return fmt.Errorf("failed to update task: %w", err)
 		}
 
 		// exit
@@ -135,9 +302,23 @@ func init() {
 
 	// define flags and add subcommand to root
 
-	editCmd.Flags().StringP("due", "d", "", "Change due date of task (YYYY-MM-DD)")
-	editCmd.Flags().BoolP("priority", "p", false, "Toggle the task priority")
+	editCmd.Flags().StringP("due", "d", "", "Change due date of task (YYYY-MM-DD, or e.g. tomorrow, friday, +1w)")
+	editCmd.Flags().StringP("priority", "p", "", "Set the task priority to high or normal, or toggle it if no value is given")
+	editCmd.Flags().Lookup("priority").NoOptDefVal = priorityToggle
 	editCmd.Flags().StringP("title", "t", "", "Change the title of task")
+	editCmd.Flags().StringSlice("add-tag", nil, "Add tags to the task, can be repeated or comma separated")
+	editCmd.Flags().StringSlice("remove-tag", nil, "Remove tags from the task, can be repeated or comma separated")
+	editCmd.Flags().BoolP("all", "a", false, "Edit all tasks, or those chosen by --where")
+	editCmd.Flags().StringArray("where", nil, "With --all, only edit tasks chosen by a selector, such as @overdue or 3-8")
+	editCmd.Flags().BoolP("editor", "e", false, "Edit tasks in your text editor ($VISUAL or $EDITOR)")
+
+	// suggest task IDs and due dates
+	editCmd.ValidArgsFunction = completeTaskIDs(anyTask)
+	_ = editCmd.RegisterFlagCompletionFunc("due", completeDueDate)
+	_ = editCmd.RegisterFlagCompletionFunc("priority", cobra.FixedCompletions([]string{"high", "normal"}, cobra.ShellCompDirectiveNoFileComp))
+	_ = editCmd.RegisterFlagCompletionFunc("add-tag", completeTags)
+	_ = editCmd.RegisterFlagCompletionFunc("remove-tag", completeTags)
+	_ = editCmd.RegisterFlagCompletionFunc("where", completeSelectors)
 
 	rootCmd.AddCommand(editCmd)
 }
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/tm-craggs/tidytask/selector"
+	"github.com/tm-craggs/tidytask/task"
+	"github.com/tm-craggs/tidytask/util"
+	"strings"
+)
+
+// editAll applies the changes given by flags to every task, or the tasks chosen by --where.
+// the affected tasks are shown for a single confirmation, then all changes are saved in one transaction
+func editAll(store task.Store, flags editFlags) error {
+
+	// bulk edits need a change, and cannot give every task the same title
+	if flags.titleChanged {
+		return fmt.Errorf("cannot use --title with --all")
+	}
+	if !flags.dueChanged && flags.priority == "" && len(flags.addTags) == 0 && len(flags.removeTags) == 0 {
+		return fmt.Errorf("no changes given; use --due, --priority, --add-tag or --remove-tag")
+	}
+
+	// get every task, or those chosen by --where
+	var tasks []task.Task
+	if len(flags.where) == 0 {
+		var err error
+		if tasks, err = store.List(task.Filter{}); err != nil {
+			return fmt.Errorf("failed to retrieve tasks: %w", err)
+		}
+	} else {
+		result, err := selector.Resolve(store, flags.where, knownStatuses())
+		if err != nil {
+			return err
+		}
+		for sel, reason := range result.Failed {
+			return fmt.Errorf("invalid --where %s: %s", sel, reason)
+		}
+		tasks = result.Tasks
+	}
+	if len(tasks) == 0 {
+		return fmt.Errorf("no tasks to edit")
+	}
+
+	// work out the changes, keeping only tasks that change
+	var changed []task.Task
+	var affected []task.Task
+	for _, t := range tasks {
+		edited := applyEdit(t, flags)
+		if len(describeChanges(t, edited)) > 0 {
+			changed = append(changed, edited)
+			affected = append(affected, t)
+		}
+	}
+	if len(changed) == 0 {
+		fmt.Println("No changes made. The tasks already have these values.")
+		return nil
+	}
+
+	// show the affected tasks and the changes for confirmation
+	if err := util.PrintTasks(affected, util.PrintOptions{}); err != nil {
+		return fmt.Errorf("failed to print tasks: %w", err)
+	}
+	fmt.Println("Changes:")
+	for _, line := range describeEditFlags(flags) {
+		fmt.Printf("  %s\n", line)
+	}
+
+	label := "tasks"
+	if len(changed) == 1 {
+		label = "task"
+	}
+	if err := confirm(fmt.Sprintf("Apply changes to %d %s?", len(changed), label)); err != nil {
+		return err
+	}
+
+	// backup database
+	if err := store.Backup(); err != nil {
+		fmt.Printf("Warning: failed to back up database: %v", err)
+	}
+
+	// save every change together
+	if err := store.UpdateMany(changed); err != nil {
+		return fmt.Errorf("failed to update tasks, no changes made: %w", err)
+	}
+
+	fmt.Printf("Updated %d %s\n", len(changed), label)
+	return nil
+}
+
+// describeEditFlags lists the changes given by flags, for confirmation
+func describeEditFlags(flags editFlags) []string {
+	var changes []string
+	if flags.dueChanged {
+		changes = append(changes, "due:      "+flags.due)
+	}
+	switch flags.priority {
+	case priorityToggle:
+		changes = append(changes, "priority: toggle")
+	case "high", "normal":
+		changes = append(changes, "priority: "+flags.priority)
+	}
+	if len(flags.addTags) > 0 {
+		changes = append(changes, "add tags: "+strings.Join(flags.addTags, ", "))
+	}
+	if len(flags.removeTags) > 0 {
+		changes = append(changes, "remove tags: "+strings.Join(flags.removeTags, ", "))
+	}
+	return changes
+}
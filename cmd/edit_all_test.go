@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tm-craggs/tidytask/task"
+)
+
+func TestApplyEdit(t *testing.T) {
+	tk := task.Task{ID: 1, Title: "One", Priority: true, Tags: []string{"home", "work"}}
+
+	tests := []struct {
+		name  string
+		flags editFlags
+		want  task.Task
+	}{
+		{"no changes", editFlags{}, tk},
+		{"toggle", editFlags{priority: priorityToggle}, task.Task{ID: 1, Title: "One", Tags: []string{"home", "work"}}},
+		{"high", editFlags{priority: "high"}, tk},
+		{"normal", editFlags{priority: "normal"}, task.Task{ID: 1, Title: "One", Tags: []string{"home", "work"}}},
+		{"due", editFlags{due: "2030-01-02", dueChanged: true}, task.Task{ID: 1, Title: "One", Due: "2030-01-02", Priority: true, Tags: []string{"home", "work"}}},
+		{"tags", editFlags{addTags: []string{"urgent", "home"}, removeTags: []string{"work", "none"}}, task.Task{ID: 1, Title: "One", Priority: true, Tags: []string{"home", "urgent"}}},
+	}
+	for _, tt := range tests {
+		if got := applyEdit(tk, tt.flags); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: applyEdit = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+
+	// the original task's tags are not changed
+	if !reflect.DeepEqual(tk.Tags, []string{"home", "work"}) {
+		t.Errorf("applyEdit changed the original tags to %v", tk.Tags)
+	}
+}
+
+func TestEditAll(t *testing.T) {
+	store := newStore(t,
+		task.Task{Title: "One", Tags: []string{"home"}},
+		task.Task{Title: "Two", Priority: true},
+		task.Task{Title: "Three", Tags: []string{"work"}},
+	)
+
+	// the changes need confirmation
+	if _, err := run(t, store, "edit", "--all", "--add-tag", "q3"); err == nil {
+		t.Fatal("expected an error without confirmation")
+	}
+	if tags := getTask(t, store, 1).Tags; !reflect.DeepEqual(tags, []string{"home"}) {
+		t.Errorf("tags changed without confirmation to %v", tags)
+	}
+
+	out := mustRun(t, store, "edit", "--all", "--add-tag", "q3", "--remove-tag", "work", "--priority", "high", "--yes")
+	assertContains(t, out, "add tags: q3", "remove tags: work", "priority: high", "Updated 3 tasks")
+	for id, want := range map[int][]string{1: {"home", "q3"}, 2: {"q3"}, 3: {"q3"}} {
+		tk := getTask(t, store, id)
+		if !reflect.DeepEqual(tk.Tags, want) || !tk.Priority {
+			t.Errorf("task %d = %+v, want tags %v and high priority", id, tk, want)
+		}
+	}
+
+	// only tasks that change are counted
+	out = mustRun(t, store, "edit", "--all", "--where", "1-2", "--remove-tag", "home", "--yes")
+	assertContains(t, out, "Updated 1 task")
+
+	out = mustRun(t, store, "edit", "--all", "--add-tag", "q3", "--yes")
+	assertContains(t, out, "No changes made. The tasks already have these values.")
+}
+
+func TestEditAllErrors(t *testing.T) {
+	store := newStore(t, task.Task{Title: "One"})
+	for _, args := range [][]string{
+		{"edit", "--all"},
+		{"edit", "--all", "--title", "Same"},
+		{"edit", "--all", "--where", "@overdue", "--priority", "high"},
+		{"edit", "--all", "--where", "9", "--priority", "high"},
+	} {
+		if _, err := run(t, store, append(args, "--yes")...); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+	if tk := getTask(t, store, 1); tk.Title != "One" || tk.Priority {
+		t.Errorf("task changed to %+v", tk)
+	}
+}
@@ -0,0 +1,276 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/tm-craggs/tidytask/selector"
+	"github.com/tm-craggs/tidytask/task"
+	"github.com/tm-craggs/tidytask/util"
+	"strconv"
+	"strings"
+)
+
+// editInEditor opens the tasks selected by args in the user's editor, then applies the changes in one transaction.
+// with no args, every open task is edited
+func editInEditor(store task.Store, args []string) error {
+
+	// get tasks to edit
+	var tasks []task.Task
+	if len(args) == 0 {
+		var err error
+		if tasks, err = store.List(task.Filter{Open: true}); err != nil {
+			return fmt.Errorf("failed to retrieve tasks: %w", err)
+		}
+	} else {
+		result, err := selector.Resolve(store, args, knownStatuses())
+		if err != nil {
+			return err
+		}
+		for sel, reason := range result.Failed {
+			return fmt.Errorf("invalid task %s: %s", sel, reason)
+		}
+		tasks = result.Tasks
+	}
+	if len(tasks) == 0 {
+		return fmt.Errorf("no tasks to edit")
+	}
+
+	// open the tasks in the editor
+	original := formatEditDocument(tasks)
+	edited, err := util.EditText(original, "tidytask-*.txt")
+	if err != nil {
+		return err
+	}
+	if edited == original {
+		fmt.Println("No changes made.")
+		return nil
+	}
+
+	// read back the edited tasks, nothing is changed if the document is invalid
+	changed, err := parseEditDocument(edited, tasks)
+	if err != nil {
+		return fmt.Errorf("invalid edit, no changes made: %w", err)
+	}
+	if len(changed) == 0 {
+		fmt.Println("No changes made.")
+		return nil
+	}
+
+	// show the changes for confirmation
+	before := make(map[int]task.Task, len(tasks))
+	for _, t := range tasks {
+		before[t.ID] = t
+	}
+	for _, t := range changed {
+		fmt.Printf("Task %d:\n", t.ID)
+		for _, line := range describeChanges(before[t.ID], t) {
+			fmt.Printf("  %s\n", line)
+		}
+	}
+
+	label := "tasks"
+	if len(changed) == 1 {
+		label = "task"
+	}
+	if err := confirm(fmt.Sprintf("Apply changes to %d %s?", len(changed), label)); err != nil {
+		return err
+	}
+
+	// backup database
+	if err := store.Backup(); err != nil {
+		fmt.Printf("Warning: failed to back up database: %v", err)
+	}
+
+	// save every change together
+	if err := store.UpdateMany(changed); err != nil {
+		return fmt.Errorf("failed to update tasks, no changes made: %w", err)
+	}
+
+	fmt.Printf("Updated %d %s\n", len(changed), label)
+	return nil
+}
+
+// formatEditDocument writes tasks as a text document for editing, a block of fields under each task ID
+func formatEditDocument(tasks []task.Task) string {
+	var b strings.Builder
+
+	b.WriteString("# Edit the tasks below, then save and close the editor to apply the changes.\n")
+	b.WriteString("# Lines starting with # are ignored. Remove a task, or leave out a field, to keep it unchanged.\n")
+	b.WriteString("#\n")
+	b.WriteString("# due:      YYYY-MM-DD, a relative date such as tomorrow or +1w, or none\n")
+	b.WriteString("# priority: high or normal\n")
+	b.WriteString("# status:   " + strings.Join(knownStatuses(), ", ") + "\n")
+	b.WriteString("# tags:     single words separated by commas, or leave empty\n")
+
+	for _, t := range tasks {
+		due := t.Due
+		if due == "" {
+			due = "none"
+		}
+		priority := "normal"
+		if t.Priority {
+			priority = "high"
+		}
+
+		fmt.Fprintf(&b, "\n[%d]\n", t.ID)
+		fmt.Fprintf(&b, "title:    %s\n", t.Title)
+		fmt.Fprintf(&b, "due:      %s\n", due)
+		fmt.Fprintf(&b, "priority: %s\n", priority)
+		fmt.Fprintf(&b, "status:   %s\n", t.Status)
+		fmt.Fprintf(&b, "tags:     %s\n", strings.Join(t.Tags, ", "))
+	}
+
+	return b.String()
+}
+
+// parseEditDocument reads an edited document written by formatEditDocument, returning the tasks that changed.
+// tasks are only edited if they were in the original document, and every field is validated
+func parseEditDocument(text string, tasks []task.Task) ([]task.Task, error) {
+
+	// index the original tasks by ID
+	originals := make(map[int]task.Task, len(tasks))
+	for _, t := range tasks {
+		originals[t.ID] = t
+	}
+
+	// edited holds each task block, in document order
+	var edited []task.Task
+	seen := make(map[int]bool)
+	current := -1
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		// skip blank lines and comments
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// a task ID starts a new block
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			id, err := strconv.Atoi(strings.TrimSpace(line[1 : len(line)-1]))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid task ID %s", n, line)
+			}
+			t, ok := originals[id]
+			if !ok {
+				return nil, fmt.Errorf("line %d: task %d was not being edited", n, id)
+			}
+			if seen[id] {
+				return nil, fmt.Errorf("line %d: task %d appears more than once", n, id)
+			}
+			seen[id] = true
+			edited = append(edited, t)
+			current = len(edited) - 1
+			continue
+		}
+
+		// anything else is a field of the current task
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected a field such as \"title: ...\" or a task ID such as [4]", n)
+		}
+		if current < 0 {
+			return nil, fmt.Errorf("line %d: field before the first task ID", n)
+		}
+		if err := setEditField(&edited[current], strings.TrimSpace(key), strings.TrimSpace(value)); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// keep only tasks with changes
+	var changed []task.Task
+	for _, t := range edited {
+		if len(describeChanges(originals[t.ID], t)) > 0 {
+			changed = append(changed, t)
+		}
+	}
+
+	return changed, nil
+}
+
+// setEditField validates value and sets the field of t named by key
+func setEditField(t *task.Task, key, value string) error {
+	switch key {
+	case "title":
+		if value == "" {
+			return fmt.Errorf("title cannot be empty")
+		}
+		t.Title = value
+	case "due":
+		if value == "" || value == "none" {
+			t.Due = ""
+			return nil
+		}
+		due, err := util.ParseDate(value)
+		if err != nil {
+			return err
+		}
+		t.Due = due
+	case "priority":
+		switch value {
+		case "high":
+			t.Priority = true
+		case "normal":
+			t.Priority = false
+		default:
+			return fmt.Errorf("invalid priority %q; use high or normal", value)
+		}
+	case "status":
+		if err := checkStatus(value); err != nil {
+			return err
+		}
+		t.Status = value
+	case "tags":
+		var tags []string
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.TrimSpace(tag); tag == "" {
+				continue
+			}
+			if err := task.CheckTag(tag); err != nil {
+				return err
+			}
+			tags = append(tags, tag)
+		}
+		t.Tags = task.NormaliseTags(tags)
+	default:
+		return fmt.Errorf("unknown field %q; use title, due, priority, status or tags", key)
+	}
+	return nil
+}
+
+// describeChanges lists each field that differs between two versions of a task, as "field: old → new"
+func describeChanges(before, after task.Task) []string {
+	var changes []string
+	add := func(field, old, new string) {
+		if old != new {
+			changes = append(changes, fmt.Sprintf("%-9s %s → %s", field+":", old, new))
+		}
+	}
+
+	orNone := func(s string) string {
+		if s == "" {
+			return "none"
+		}
+		return s
+	}
+	priority := func(high bool) string {
+		if high {
+			return "high"
+		}
+		return "normal"
+	}
+
+	add("id", strconv.Itoa(before.ID), strconv.Itoa(after.ID))
+	add("title", strconv.Quote(before.Title), strconv.Quote(after.Title))
+	add("due", orNone(before.Due), orNone(after.Due))
+	add("priority", priority(before.Priority), priority(after.Priority))
+	add("status", before.Status, after.Status)
+	add("tags", orNone(strings.Join(before.Tags, ", ")), orNone(strings.Join(after.Tags, ", ")))
+
+	return changes
+}
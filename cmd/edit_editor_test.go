@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tm-craggs/tidytask/task"
+)
+
+func TestEditDocumentRoundTrip(t *testing.T) {
+	tasks := []task.Task{
+		{ID: 1, Title: "Write report", Due: "2030-01-02", Priority: true, Status: task.StatusDoing, Tags: []string{"work"}},
+		{ID: 4, Title: "Plain", Status: task.StatusTodo},
+	}
+
+	// an unedited document changes nothing
+	changed, err := parseEditDocument(formatEditDocument(tasks), tasks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 0 {
+		t.Errorf("unedited document changed %+v", changed)
+	}
+}
+
+func TestParseEditDocument(t *testing.T) {
+	tasks := []task.Task{
+		{ID: 1, Title: "One", Status: task.StatusTodo},
+		{ID: 2, Title: "Two", Status: task.StatusTodo, Tags: []string{"home"}},
+		{ID: 3, Title: "Three", Status: task.StatusTodo},
+	}
+
+	doc := `# comment
+[2]
+title:    Two, renamed
+due:      2030-06-01
+priority: high
+status:   blocked
+tags:     work, home,  work
+
+[1]
+title: One
+
+[ 3 ]
+tags:
+`
+	changed, err := parseEditDocument(doc, tasks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// only task 2 changed; task 3 already had no tags, and task 1 is missing fields but otherwise unchanged
+	want := []task.Task{{ID: 2, Title: "Two, renamed", Due: "2030-06-01", Priority: true, Status: task.StatusBlocked, Tags: []string{"home", "work"}}}
+	if !reflect.DeepEqual(changed, want) {
+		t.Errorf("changed = %+v, want %+v", changed, want)
+	}
+}
+
+func TestParseEditDocumentErrors(t *testing.T) {
+	tasks := []task.Task{{ID: 1, Title: "One", Status: task.StatusTodo}}
+
+	tests := []struct {
+		doc  string
+		want string
+	}{
+		{"title: One", "line 1: field before the first task ID"},
+		{"[x]", "line 1: invalid task ID [x]"},
+		{"[9]", "line 1: task 9 was not being edited"},
+		{"[1]\n[1]", "line 2: task 1 appears more than once"},
+		{"[1]\njust text", "line 2: expected a field"},
+		{"[1]\ntitle:", "line 2: title cannot be empty"},
+		{"[1]\ndue: someday", "line 2:"},
+		{"[1]\npriority: urgent", `line 2: invalid priority "urgent"`},
+		{"[1]\nstatus: waiting", "line 2:"},
+		{"[1]\ntags: two words", "line 2:"},
+		{"[1]\ncolour: red", `line 2: unknown field "colour"`},
+	}
+	for _, tt := range tests {
+		_, err := parseEditDocument(tt.doc, tasks)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("parseEditDocument(%q) error = %v, want %q", tt.doc, err, tt.want)
+		}
+	}
+}
+
+func TestDescribeChanges(t *testing.T) {
+	before := task.Task{ID: 1, Title: "One", Status: task.StatusTodo, Tags: []string{"home"}}
+	after := task.Task{ID: 1, Title: "Uno", Due: "2030-01-02", Priority: true, Status: task.StatusTodo}
+
+	want := []string{
+		`title:    "One" → "Uno"`,
+		"due:      none → 2030-01-02",
+		"priority: normal → high",
+		"tags:     home → none",
+	}
+	if got := describeChanges(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("describeChanges = %q, want %q", got, want)
+	}
+	if got := describeChanges(before, before); len(got) != 0 {
+		t.Errorf("describeChanges of an unchanged task = %q", got)
+	}
+}
+
+func TestEditInEditor(t *testing.T) {
+	store := newStore(t, task.Task{Title: "One"}, task.Task{Title: "Two"}, task.Task{Title: "Done", Status: task.StatusDone})
+
+	// the editor renames task two and marks it high priority
+	t.Setenv("VISUAL", "sed -i -e s/^title:.*Two$/title:Renamed/ -e /Renamed/{n;n;s/normal/high/}")
+
+	// changes are not applied without confirmation
+	if _, err := run(t, store, "edit", "--editor"); err == nil {
+		t.Fatal("expected an error without confirmation")
+	}
+	if getTask(t, store, 2).Title != "Two" {
+		t.Error("changes applied without confirmation")
+	}
+
+	out := mustRun(t, store, "edit", "--editor", "--yes")
+	assertContains(t, out, "Task 2:", `"Two" → "Renamed"`, "normal → high", "Updated 1 task")
+	if tk := getTask(t, store, 2); tk.Title != "Renamed" || !tk.Priority {
+		t.Errorf("task 2 = %+v", tk)
+	}
+
+	// an editor that changes nothing leaves the tasks alone
+	t.Setenv("VISUAL", "true")
+	out = mustRun(t, store, "edit", "--editor", "1")
+	assertContains(t, out, "No changes made.")
+
+	// an invalid edit changes nothing
+	t.Setenv("VISUAL", "sed -i s/^priority:.*/priority:urgent/")
+	if _, err := run(t, store, "edit", "--editor", "--yes", "1"); err == nil || !strings.Contains(err.Error(), "no changes made") {
+		t.Errorf("invalid edit error = %v", err)
+	}
+	if getTask(t, store, 1).Priority {
+		t.Error("invalid edit changed task 1")
+	}
+}
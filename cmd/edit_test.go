@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/tm-craggs/tidytask/task"
+)
+
+func TestEdit(t *testing.T) {
+	store := newStore(t, task.Task{Title: "Old title", Tags: []string{"keep", "drop"}})
+
+	out := mustRun(t, store, "edit", "1", "--title", "New title", "--due", "2030-05-06", "--priority",
+		"--add-tag", "new", "--remove-tag", "drop", "--yes")
+	assertContains(t, out, "Task updated")
+
+	got := getTask(t, store, 1)
+	if got.Title != "New title" || got.Due != "2030-05-06" || !got.Priority {
+		t.Errorf("edited task = %+v", got)
+	}
+	if !slices.Equal(got.Tags, []string{"keep", "new"}) {
+		t.Errorf("tags = %v, want [keep new]", got.Tags)
+	}
+
+	// --priority with no value toggles it, and an explicit value sets it
+	mustRun(t, store, "edit", "1", "--priority", "--yes")
+	if getTask(t, store, 1).Priority {
+		t.Error("--priority did not toggle the priority off")
+	}
+	mustRun(t, store, "edit", "1", "--priority", "high", "--yes")
+	if !getTask(t, store, 1).Priority {
+		t.Error("--priority high did not set the priority")
+	}
+}
+
+func TestEditAllWhere(t *testing.T) {
+	store := newStore(t,
+		task.Task{Title: "One", Due: "2030-01-01"},
+		task.Task{Title: "Two", Due: "2030-01-01"},
+		task.Task{Title: "Three", Due: "2030-01-01"},
+	)
+
+	mustRun(t, store, "edit", "--all", "--where", "2-3", "--due", "2030-02-02", "--yes")
+	for id, want := range map[int]string{1: "2030-01-01", 2: "2030-02-02", 3: "2030-02-02"} {
+		if got := getTask(t, store, id).Due; got != want {
+			t.Errorf("task %d due = %s, want %s", id, got, want)
+		}
+	}
+}
+
+func TestEditErrors(t *testing.T) {
+	store := newStore(t, task.Task{Title: "Title", Due: "2030-01-01"})
+	for _, args := range [][]string{
+		{"edit", "1", "2"},
+		{"edit", "9", "--title", "x"},
+		{"edit", "1", "--due", "not-a-date"},
+		{"edit", "1", "--where", "@overdue", "--title", "x"},
+		{"edit", "1", "--title", "x", "--editor"},
+	} {
+		if _, err := run(t, store, append(args, "--yes")...); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+	if got := getTask(t, store, 1); got.Title != "Title" || got.Due != "2030-01-01" {
+		t.Errorf("task changed to %+v", got)
+	}
+}
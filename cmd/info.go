@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/tm-craggs/tidytask/task"
+	"os"
+)
+
+// infoCmd represents the info subcommand
+var infoCmd = &cobra.Command{
+	Use:                   "info",
+	DisableFlagsInUseLine: true,
+	Short:                 "Show information about TidyTask and the active database",
+	Long: `The 'info' command shows the TidyTask version and which database commands are operating on.
+
+The database is either global, stored in your user config directory, or project-local, discovered from a
+'.tidytask' directory or '.tidytask.db' file in the current directory or any parent, or custom, chosen with the
+--db flag or the TIDYTASK_DB environment variable.`,
+
+	// command logic
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		// check args
+		if len(args) > 0 {
+			return fmt.Errorf("unexpected arguments: %v; use --help for usage information", args)
+		}
+
+		// get store
+		store, err := getStore(cmd)
+		if err != nil {
+			return err
+		}
+		dbPath := store.Path()
+
+		// check whether an undo is available
+		backup := "none"
+		if dbPath == task.MemoryPath {
+			backup = "unavailable for in-memory databases"
+		} else if _, err := os.Stat(dbPath + ".bak"); err == nil {
+			backup = "available"
+		}
+
+		// print details
+		fmt.Printf("Version:  %s\n", rootCmd.Version)
+		fmt.Printf("Database: %s (%s)\n", dbPath, dbScope)
+		fmt.Printf("Backup:   %s\n", backup)
+
+		// exit
+		return nil
+	},
+}
+
+// command initialisation
+func init() {
+
+	// add subcommand to root
+	rootCmd.AddCommand(infoCmd)
+}
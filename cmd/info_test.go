@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tm-craggs/tidytask/task"
+)
+
+func TestInfo(t *testing.T) {
+	root := t.TempDir()
+	config := filepath.Join(root, "config")
+	t.Setenv("XDG_CONFIG_HOME", config)
+	globalPath := filepath.Join(config, "tidytask", "tasks.db")
+
+	project := filepath.Join(root, "project")
+	if err := os.MkdirAll(filepath.Join(project, ".tidytask"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	projectPath := filepath.Join(project, ".tidytask", "tasks.db")
+	custom := filepath.Join(root, "custom.db")
+
+	tests := []struct {
+		name string
+		dir  string
+		env  string
+		args []string
+		want []string
+	}{
+		{"global", root, "", nil, []string{"Database: " + globalPath + " (global)"}},
+		{"project", project, "", nil, []string{"Database: " + projectPath + " (project)"}},
+		{"--global in a project", project, "", []string{"--global"}, []string{"Database: " + globalPath + " (global)"}},
+		{"environment", project, custom, nil, []string{"Database: " + custom + " (custom)"}},
+		{"--db", project, filepath.Join(root, "env.db"), []string{"--db", custom}, []string{"Database: " + custom + " (custom)"}},
+		{"in memory", project, "", []string{"--db", task.MemoryPath},
+			[]string{"Database: :memory: (custom)", "Backup:   unavailable for in-memory databases"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(tt.dir)
+			t.Setenv(task.EnvDB, tt.env)
+
+			out := mustRun(t, nil, append([]string{"info"}, tt.args...)...)
+			assertContains(t, out, append([]string{"Version:  " + rootCmd.Version}, tt.want...)...)
+		})
+	}
+}
+
+func TestInfoBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.db")
+
+	assertContains(t, mustRun(t, nil, "info", "--db", path), "Backup:   none")
+	mustRun(t, nil, "add", "--db", path, "Task")
+	assertContains(t, mustRun(t, nil, "info", "--db", path), "Backup:   available")
+}
+
+func TestInfoMissingDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "tasks.db")
+	if _, err := run(t, nil, "info", "--db", path); err == nil {
+		t.Error("expected an error when the --db directory does not exist")
+	}
+}
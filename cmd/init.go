@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/tm-craggs/tidytask/task"
+	"os"
+	"path/filepath"
+)
+
+// initCmd represents the init subcommand
+var initCmd = &cobra.Command{
+	Use:                   "init",
+	DisableFlagsInUseLine: true,
+	Short:                 "Create a project-local to-do list in the current directory",
+	Long: `The 'init' command creates a project-local to-do list in the current directory.
+
+A '.tidytask' directory is created to hold the database. Whenever you run TidyTask in this directory, or any
+directory below it, commands operate on the project's to-do list instead of your user to-do list.
+
+Use the --global flag on any command to operate on your user to-do list from inside a project.`,
+
+	Example: `  tidytask init
+  > Create a to-do list for the project in the current directory
+
+  tidytask list --global
+  > Show your user to-do list while inside a project`,
+
+	Annotations: map[string]string{annotationNoDB: "true"},
+
+	// command logic
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		// check args
+		if len(args) > 0 {
+			return fmt.Errorf("unexpected arguments: %v; use --help for usage information", args)
+		}
+
+		// get current directory
+		wd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+
+		// create the .tidytask directory
+		path, err := task.CreateLocalDB(wd)
+		if err != nil {
+			return err
+		}
+
+		// create the database and tasks table
+		store, err := task.Open(path)
+		if err != nil {
+			return fmt.Errorf("DB creation error: %w", err)
+		}
+		if err := store.Close(); err != nil {
+			return fmt.Errorf("DB closing error: %w", err)
+		}
+
+		// exit
+		fmt.Printf("Initialised empty TidyTask database in %s\n", filepath.Dir(path))
+		return nil
+	},
+}
+
+// command initialisation
+func init() {
+
+	// add subcommand to root
+	rootCmd.AddCommand(initCmd)
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInit(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	out := mustRun(t, nil, "init")
+	assertContains(t, out, "Initialised empty TidyTask database in "+filepath.Join(dir, ".tidytask"))
+	if _, err := os.Stat(filepath.Join(dir, ".tidytask", "tasks.db")); err != nil {
+		t.Errorf("database not created: %v", err)
+	}
+
+	// tasks added in the project go to its database
+	mustRun(t, nil, "add", "Project task")
+	assertContains(t, mustRun(t, nil, "list"), "Project task")
+	assertNotContains(t, mustRun(t, nil, "list", "--global"), "Project task")
+
+	// a second init, here or below the project, is refused rather than replacing the database
+	if _, err := run(t, nil, "init"); err == nil {
+		t.Error("expected an error initialising twice")
+	}
+	assertContains(t, mustRun(t, nil, "list"), "Project task")
+}
+
+func TestInitRefusesSingleFileDatabase(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.WriteFile(filepath.Join(dir, ".tidytask.db"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := run(t, nil, "init"); err == nil {
+		t.Error("expected an error with an existing .tidytask.db")
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".tidytask")); err == nil {
+		t.Error("init created .tidytask next to .tidytask.db")
+	}
+}
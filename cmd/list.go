@@ -6,6 +6,8 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/tm-craggs/tidytask/task"
 	"github.com/tm-craggs/tidytask/util"
+	"strings"
+	"time"
 )
 
 // create struct that defines the available flags for list command
@@ -14,6 +16,13 @@ type listFlags struct {
 	complete bool
 	open     bool
 	normal   bool
+	sort     string
+	age      bool
+	stale    string
+	status   string
+	due      string
+	tag      string
+	handles  bool
 }
 
 // helper function to parse flags with error handling
@@ -33,6 +42,28 @@ func getListFlags(cmd *cobra.Command) (listFlags, error) {
 	if flags.normal, err = cmd.Flags().GetBool("normal"); err != nil {
 		return flags, fmt.Errorf("failed to parse --normal flag: %w", err)
 	}
+	if flags.sort, err = cmd.Flags().GetString("sort"); err != nil {
+		return flags, fmt.Errorf("failed to parse --sort flag: %w", err)
+	}
+	if flags.age, err = cmd.Flags().GetBool("age"); err != nil {
+		return flags, fmt.Errorf("failed to parse --age flag: %w", err)
+	}
+	if flags.stale, err = cmd.Flags().GetString("stale"); err != nil {
+		return flags, fmt.Errorf("failed to parse --stale flag: %w", err)
+	}
+	if flags.status, err = cmd.Flags().GetString("status"); err != nil {
+		return flags, fmt.Errorf("failed to parse --status flag: %w", err)
+	}
+
+	if flags.due, err = cmd.Flags().GetString("due"); err != nil {
+		return flags, fmt.Errorf("failed to parse --due flag: %w", err)
+	}
+	if flags.handles, err = cmd.Flags().GetBool("handles"); err != nil {
+		return flags, fmt.Errorf("failed to parse --handles flag: %w", err)
+	}
+	if flags.tag, err = cmd.Flags().GetString("tag"); err != nil {
+		return flags, fmt.Errorf("failed to parse --tag flag: %w", err)
+	}
 
 	return flags, nil
 }
@@ -43,7 +74,15 @@ var listCmd = &cobra.Command{
 	Short: "Display tasks in your to-do list",
 	Long: `The 'list' command displays all tasks in your to-do list. 
 
-Optionally, you can use flags to to narrow the results and only show tasks that meet certain criteria.`,
+Optionally, you can use flags to to narrow the results and only show tasks that meet certain criteria.
+
+Use --sort to change the order of the list, and --age to show how long ago each task was created.
+The --stale flag finds open tasks that have not been changed for a period, given as a number of
+days (d), weeks (w), months (m) or years (y).
+
+The --due flag shows only tasks due on a date, within a period from today such as 2w, or at a relative
+time: overdue, today, tomorrow, this-week, next-week or this-month. Relative dates are worked out each
+time the list is shown, so they can be used in saved views.`,
 
 	Example: `  tidytask list
   > Show all tasks
@@ -52,7 +91,22 @@ Optionally, you can use flags to to narrow the results and only show tasks that
   > Show only high priority tasks
 
   tidytask list --complete --priority
-  > Show only completed, high priority tasks`,
+  > Show only completed, high priority tasks
+
+  tidytask list --status doing
+  > Show only tasks that are in progress
+
+  tidytask list --sort created --age
+  > Show all tasks, oldest first, with their age
+
+  tidytask list --stale 30d
+  > Show open tasks that have not been changed in 30 days
+
+  tidytask list --tag sprint-5
+  > Show only tasks tagged sprint-5
+
+  tidytask list --due this-week --priority
+  > Show high priority tasks due between today and Sunday`,
 
 	RunE: func(cmd *cobra.Command, args []string) error {
 
@@ -61,6 +115,12 @@ Optionally, you can use flags to to narrow the results and only show tasks that
 			return fmt.Errorf("unexpected arguments: %v; use --help for usage information", args)
 		}
 
+		// get store
+		store, err := getStore(cmd)
+		if err != nil {
+			return err
+		}
+
 		// get flags
 		flags, err := getListFlags(cmd)
 		if err != nil {
@@ -76,17 +136,64 @@ Optionally, you can use flags to to narrow the results and only show tasks that
 			return fmt.Errorf("conflicting flags: cannot use --complete and --open together")
 		}
 
-		// get tasks
-		tasks, err := task.GetTasks()
+		// check status exists
+		if flags.status != "" {
+			if err := checkStatus(flags.status); err != nil {
+				return err
+			}
+		}
+
+		// build filter from flags
+		filter := task.Filter{
+			Complete: flags.complete,
+			Open:     flags.open,
+			Priority: flags.priority,
+			Normal:   flags.normal,
+			Status:   flags.status,
+			Tag:      flags.tag,
+		}
+
+		// stale tasks are open tasks not changed within the period
+		if flags.stale != "" {
+			if flags.complete {
+				return fmt.Errorf("conflicting flags: cannot use --stale and --complete together")
+			}
+			period, err := util.ParsePeriod(flags.stale)
+			if err != nil {
+				return err
+			}
+			filter.Open = true
+			filter.UpdatedBefore = time.Now().Add(-period)
+		}
+
+		// due date range, resolved against today's date
+		if flags.due != "" {
+			if filter.DueFrom, filter.DueTo, err = util.ParseDueRange(flags.due); err != nil {
+				return err
+			}
+
+			// overdue tasks are open tasks due before today
+			if flags.due == "overdue" {
+				if flags.complete {
+					return fmt.Errorf("conflicting flags: cannot use --due overdue and --complete together")
+				}
+				filter.Open = true
+			}
+		}
+
+		// get tasks, filtered using flags
+		tasks, err := store.List(filter)
 		if err != nil {
 			return fmt.Errorf("failed to get tasks: %w", err)
 		}
 
-		// filter tasks using flags
-		filteredTasks := util.FilterTasks(tasks, flags.complete, flags.priority, flags.open, flags.normal)
+		// reorder tasks if requested
+		if err := task.SortTasks(tasks, flags.sort); err != nil {
+			return err
+		}
 
-		// print tasks in table format
-		err = util.PrintTasks(filteredTasks)
+		// print tasks in table format, stale tasks always show their age
+		err = util.PrintTasks(tasks, util.PrintOptions{Age: flags.age || flags.stale != "", Handles: flags.handles})
 		if err != nil {
 			if errors.Is(err, util.ErrNoTasks) {
 				fmt.Println("No tasks. Your to-do list is empty.")
@@ -109,6 +216,21 @@ func init() {
 	listCmd.Flags().BoolP("complete", "c", false, "Show only complete tasks ")
 	listCmd.Flags().BoolP("open", "o", false, "Show only open (incomplete) tasks")
 	listCmd.Flags().BoolP("normal", "n", false, "Show only normal priority tasks")
+	listCmd.Flags().StringP("sort", "s", task.SortDefault,
+		"Order tasks by "+strings.Join(task.SortOrders, ", "))
+	listCmd.Flags().String("status", "", "Show only tasks with the given status, e.g. doing")
+	listCmd.Flags().Bool("age", false, "Show how long ago each task was created")
+	listCmd.Flags().Bool("handles", false, "Show the stable handle of each task, which can be used instead of its ID")
+	listCmd.Flags().String("due", "", "Show only tasks due on a date, within a period, or e.g. today, this-week, overdue")
+	listCmd.Flags().String("tag", "", "Show only tasks with the given tag")
+	listCmd.Flags().String("stale", "", "Show only open tasks not changed within a period (e.g. 30d, 2w)")
+
+	// suggest flag values
+	_ = listCmd.RegisterFlagCompletionFunc("due", completeDueRange)
+	_ = listCmd.RegisterFlagCompletionFunc("status", completeStatus)
+	_ = listCmd.RegisterFlagCompletionFunc("tag", completeTags)
+	_ = listCmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions(task.SortOrders, cobra.ShellCompDirectiveNoFileComp))
+	_ = listCmd.RegisterFlagCompletionFunc("stale", cobra.FixedCompletions([]string{"7d", "2w", "30d", "6m"}, cobra.ShellCompDirectiveNoFileComp))
 
 	rootCmd.AddCommand(listCmd)
 }
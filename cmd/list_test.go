@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tm-craggs/tidytask/task"
+)
+
+func TestList(t *testing.T) {
+	store := newStore(t,
+		task.Task{Title: "Open normal"},
+		task.Task{Title: "Open priority", Priority: true},
+		task.Task{Title: "Finished", Status: task.StatusDone},
+		task.Task{Title: "Tagged", Tags: []string{"sprint-5"}},
+	)
+
+	out := mustRun(t, store, "list")
+	assertContains(t, out, "Open normal", "Open priority", "Finished", "Tagged", "sprint-5")
+
+	out = mustRun(t, store, "list", "--priority")
+	assertContains(t, out, "Open priority")
+	assertNotContains(t, out, "Open normal", "Finished")
+
+	out = mustRun(t, store, "list", "--complete")
+	assertContains(t, out, "Finished")
+	assertNotContains(t, out, "Open normal", "Open priority")
+
+	out = mustRun(t, store, "list", "--open")
+	assertContains(t, out, "Open normal", "Open priority")
+	assertNotContains(t, out, "Finished")
+
+	out = mustRun(t, store, "list", "--tag", "sprint-5")
+	assertContains(t, out, "Tagged")
+	assertNotContains(t, out, "Open normal")
+
+	// listing never changes the tasks
+	if ids := taskIDs(t, store); len(ids) != 4 {
+		t.Errorf("task IDs = %v, want 4 tasks", ids)
+	}
+}
+
+func TestListEmpty(t *testing.T) {
+	out := mustRun(t, newStore(t), "list")
+	assertContains(t, out, "No tasks. Your to-do list is empty.")
+}
+
+func TestListConflictingFlags(t *testing.T) {
+	store := newStore(t, task.Task{Title: "Task"})
+	if _, err := run(t, store, "list", "--priority", "--normal"); err == nil {
+		t.Error("expected an error for --priority with --normal")
+	}
+	if _, err := run(t, store, "list", "--complete", "--open"); err == nil {
+		t.Error("expected an error for --complete with --open")
+	}
+}
+
+func TestListTimestamps(t *testing.T) {
+	store := newStore(t, task.Task{Title: "First"}, task.Task{Title: "Second", Priority: true})
+
+	// tasks added together are ordered by ID
+	out := mustRun(t, store, "list", "--sort", "created", "--age")
+	assertContains(t, out, "Today")
+	if first, second := strings.Index(out, "First"), strings.Index(out, "Second"); first < 0 || second < first {
+		t.Errorf("tasks not in creation order:\n%s", out)
+	}
+
+	// nothing has gone unchanged for a day
+	out = mustRun(t, store, "list", "--stale", "1d")
+	assertNotContains(t, out, "First", "Second")
+
+	if _, err := run(t, store, "list", "--sort", "title"); err == nil {
+		t.Error("expected an error for an unknown sort order")
+	}
+	if _, err := run(t, store, "list", "--stale", "soon"); err == nil {
+		t.Error("expected an error for an invalid period")
+	}
+	if _, err := run(t, store, "list", "--stale", "1d", "--complete"); err == nil {
+		t.Error("expected an error for --stale with --complete")
+	}
+}
+
+func TestListHandles(t *testing.T) {
+	store := newStore(t, task.Task{Title: "One"}, task.Task{Title: "Two"})
+	handle := getTask(t, store, 2).Handle
+
+	assertNotContains(t, mustRun(t, store, "list"), handle)
+	out := mustRun(t, store, "list", "--handles")
+	assertContains(t, out, "HANDLE", handle)
+
+	// handles stay the same after the IDs are compacted
+	mustRun(t, store, "remove", "1", "--yes")
+	mustRun(t, store, "renumber", "--yes")
+	if got := getTask(t, store, 1).Handle; got != handle {
+		t.Errorf("renumbered task has handle %s, want %s", got, handle)
+	}
+}
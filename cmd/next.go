@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/tm-craggs/tidytask/task"
+	"github.com/tm-craggs/tidytask/util"
+	"math"
+	"sort"
+	"strings"
+)
+
+// nextCmd represents the next subcommand
+var nextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Recommend which tasks to work on next",
+	Long: `The 'next' command scores each open task by urgency and shows the most urgent, with the parts that make
+up each score.
+
+The score adds up:
+  priority  6 for high priority tasks
+  due       up to 12, growing from 14 days before the due date to the full amount on the day
+  overdue   up to 4, growing with each day overdue to the full amount after a week
+  age       up to 2, growing with age to the full amount after a year
+  blocked   -5 for blocked tasks, so they rank lower
+
+The amounts can be changed in the "urgency" section of config.json, for example
+{"urgency": {"priority": 10, "blocked": -20}}. Amounts that are not set keep their defaults.`,
+
+	Example: `  tidytask next
+  > Show the 3 most urgent tasks
+
+  tidytask next -n 10
+  > Show the 10 most urgent tasks`,
+
+	// main command logic
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		// check args
+		if len(args) > 0 {
+			return fmt.Errorf("unexpected arguments: %v; use --help for usage information", args)
+		}
+
+		// get flags
+		count, err := cmd.Flags().GetInt("count")
+		if err != nil {
+			return fmt.Errorf("failed to parse --count flag: %w", err)
+		}
+		if count < 1 {
+			return fmt.Errorf("invalid --count value %d; must be at least 1", count)
+		}
+
+		// get store
+		store, err := getStore(cmd)
+		if err != nil {
+			return err
+		}
+
+		// get open tasks
+		tasks, err := store.List(task.Filter{Open: true})
+		if err != nil {
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+		if len(tasks) == 0 {
+			fmt.Println("No open tasks.")
+			return nil
+		}
+
+		// score each task, most urgent first, then by ID
+		coefficients := urgencyCoefficients()
+		today := util.Today()
+		terms := make(map[int][]task.UrgencyTerm, len(tasks))
+		scores := make(map[int]float64, len(tasks))
+		for _, t := range tasks {
+			terms[t.ID] = t.Urgency(coefficients, today)
+			scores[t.ID] = task.UrgencyScore(terms[t.ID])
+		}
+		sort.SliceStable(tasks, func(i, j int) bool {
+			a, b := tasks[i], tasks[j]
+			if scores[a.ID] != scores[b.ID] {
+				return scores[a.ID] > scores[b.ID]
+			}
+			return a.ID < b.ID
+		})
+
+		// print the most urgent tasks with their scores
+		for i, t := range tasks[:min(count, len(tasks))] {
+			if i > 0 {
+				fmt.Println()
+			}
+			title, due, status, _ := util.FormatTask(t)
+			fmt.Printf("%d. %s (%d)  %s  %s\n", i+1, title, t.ID, due, status)
+			fmt.Printf("   urgency %s\n", formatUrgency(scores[t.ID], terms[t.ID]))
+		}
+
+		// exit
+		return nil
+	},
+}
+
+// urgencyCoefficients returns the urgency coefficients from the config, using the defaults for any not set
+func urgencyCoefficients() task.UrgencyCoefficients {
+	c := task.DefaultUrgency
+	for _, setting := range []struct {
+		value *float64
+		field *float64
+	}{
+		{cfg.Urgency.Priority, &c.Priority},
+		{cfg.Urgency.Due, &c.Due},
+		{cfg.Urgency.Overdue, &c.Overdue},
+		{cfg.Urgency.Age, &c.Age},
+		{cfg.Urgency.Blocked, &c.Blocked},
+	} {
+		if setting.value != nil {
+			*setting.field = *setting.value
+		}
+	}
+	return c
+}
+
+// formatUrgency shows a score and the parts it is made of, such as "14.5 = priority 6.0 + due 8.5"
+func formatUrgency(score float64, terms []task.UrgencyTerm) string {
+	if len(terms) == 0 {
+		return "0.0"
+	}
+
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		sign := "+"
+		if term.Value < 0 {
+			sign = "-"
+		}
+		if i == 0 {
+			sign = strings.TrimPrefix(sign, "+")
+		}
+		parts[i] = fmt.Sprintf("%s %s %.1f", sign, term.Name, math.Abs(term.Value))
+	}
+	return fmt.Sprintf("%.1f = %s", score, strings.TrimSpace(strings.Join(parts, " ")))
+}
+
+// command initialisation
+func init() {
+
+	// define flags and add subcommand to root
+	nextCmd.Flags().IntP("count", "n", 3, "Number of tasks to show")
+	rootCmd.AddCommand(nextCmd)
+}
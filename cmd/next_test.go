@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tm-craggs/tidytask/task"
+)
+
+func TestFormatUrgency(t *testing.T) {
+	tests := []struct {
+		terms []task.UrgencyTerm
+		want  string
+	}{
+		{nil, "0.0"},
+		{[]task.UrgencyTerm{{Name: "priority", Value: 6}, {Name: "due", Value: 8.5}}, "14.5 = priority 6.0 + due 8.5"},
+		{[]task.UrgencyTerm{{Name: "due", Value: 12}, {Name: "blocked", Value: -5}}, "7.0 = due 12.0 - blocked 5.0"},
+		{[]task.UrgencyTerm{{Name: "blocked", Value: -5}}, "-5.0 = - blocked 5.0"},
+	}
+	for _, tt := range tests {
+		if got := formatUrgency(task.UrgencyScore(tt.terms), tt.terms); got != tt.want {
+			t.Errorf("formatUrgency(%v) = %q, want %q", tt.terms, got, tt.want)
+		}
+	}
+}
+
+func TestUrgencyCoefficients(t *testing.T) {
+	store := newStore(t)
+
+	mustRun(t, store, "next")
+	if got := urgencyCoefficients(); got != task.DefaultUrgency {
+		t.Errorf("urgencyCoefficients without a config = %+v, want the defaults", got)
+	}
+
+	// coefficients that are not set keep their defaults
+	writeConfig(t, `{"urgency": {"priority": 10, "blocked": 0}}`)
+	mustRun(t, store, "next")
+	want := task.DefaultUrgency
+	want.Priority = 10
+	want.Blocked = 0
+	if got := urgencyCoefficients(); got != want {
+		t.Errorf("urgencyCoefficients = %+v, want %+v", got, want)
+	}
+}
+
+func TestNext(t *testing.T) {
+	store := newStore(t,
+		task.Task{Title: "Someday"},
+		task.Task{Title: "Due today", Due: dueIn(0)},
+		task.Task{Title: "Important", Priority: true},
+		task.Task{Title: "Overdue", Due: dueIn(-7), Status: task.StatusBlocked},
+		task.Task{Title: "Done", Priority: true, Status: task.StatusDone},
+	)
+
+	// the most urgent tasks come first, with the parts of their scores
+	out := mustRun(t, store, "next")
+	assertContains(t, out,
+		"1. Due today (2)", "urgency 12.0 = due 12.0",
+		"2. Overdue (4)", "urgency 11.0 = due 12.0 + overdue 4.0 - blocked 5.0",
+		"3. Important (3)", "urgency 6.0 = priority 6.0",
+	)
+	assertNotContains(t, out, "Someday", "Done")
+
+	// --count shows more, and ties are broken by ID
+	out = mustRun(t, store, "next", "-n", "10")
+	assertContains(t, out, "4. Someday (1)", "urgency 0.0")
+	if strings.Index(out, "Due today") > strings.Index(out, "Someday") {
+		t.Errorf("tasks are out of order:\n%s", out)
+	}
+
+	// the config changes the ranking
+	writeConfig(t, `{"urgency": {"priority": 20}}`)
+	out = mustRun(t, store, "next", "-n", "1")
+	assertContains(t, out, "1. Important (3)", "urgency 20.0 = priority 20.0")
+	assertNotContains(t, out, "Due today")
+}
+
+func TestNextEmpty(t *testing.T) {
+	store := newStore(t, task.Task{Title: "Done", Status: task.StatusDone})
+	assertContains(t, mustRun(t, store, "next"), "No open tasks.")
+}
+
+func TestNextErrors(t *testing.T) {
+	store := newStore(t, task.Task{Title: "One"})
+	for _, args := range [][]string{
+		{"next", "extra"},
+		{"next", "-n", "0"},
+		{"next", "-n", "many"},
+	} {
+		if _, err := run(t, store, args...); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+}
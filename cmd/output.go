@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/spf13/cobra"
+)
+
+// Output formats accepted by --output
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+// addOutputFlag defines the --output flag on cmd, with completion for the formats
+func addOutputFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("output", "o", outputText, "Output format: text or json")
+	_ = cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(
+		[]string{outputText, outputJSON}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// getOutputFlag returns the format chosen with --output, checking it is known
+func getOutputFlag(cmd *cobra.Command) (string, error) {
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return "", fmt.Errorf("failed to parse --output flag: %w", err)
+	}
+	if output != outputText && output != outputJSON {
+		return "", fmt.Errorf("invalid output format %q; use %s or %s", output, outputText, outputJSON)
+	}
+	return output, nil
+}
+
+// printJSON prints v as indented JSON
+func printJSON(v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
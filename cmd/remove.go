@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/spf13/cobra"
 	"github.com/tm-craggs/tidytask/task"
-	"github.com/tm-craggs/tidytask/util"
 	"sort"
 	"strconv"
 	"strings"
@@ -56,6 +55,16 @@ This limits the scope of the removal batch operation to tasks meeting the given
 
 You must only use one method. Supplying task IDs together with the --all flag for batch removal causes an error.
 
+Instead of single IDs you can give ranges such as 3-8, lists such as 1,4,9, 'last' for the most recently
+added task, or selectors such as @overdue, @today, @this-week, @priority or @doing. When ranges or selectors
+are used, the selected tasks are shown and you are asked to confirm first.
+
+A task's handle, such as k3fa, can be used in place of its ID. Handles are shown by 'list --handles' and never
+change, even when IDs do.
+
+Run 'remove' without task IDs in a terminal to choose tasks from a list: type to filter, press space to select
+tasks and enter to confirm.
+
 When combining constraints, such as --priority and --complete, it will only remove tasks that meet all conditions.`,
 	Example: `  tidytask remove 1
   > Remove task 1
@@ -63,6 +72,9 @@ When combining constraints, such as --priority and --complete, it will only remo
   tidytask remove 1 2 3
   > Remove tasks 1, 2 and 3
 
+  tidytask remove 4,7 last
+  > Remove tasks 4 and 7, and the most recently added task
+
   tidytask remove --all
   > Remove all tasks
 
@@ -75,15 +87,27 @@ When combining constraints, such as --priority and --complete, it will only remo
 	// main command logic
 	RunE: func(cmd *cobra.Command, args []string) error {
 
+		// get store
+		store, err := getStore(cmd)
+		if err != nil {
+			return err
+		}
+
 		// get flags
 		flags, err := getRemoveFlags(cmd)
 		if err != nil {
 			return err
 		}
 
-		// disallow no input
+		// with no input, choose tasks interactively, which fails outside a terminal
 		if len(args) == 0 && !flags.all {
-			return fmt.Errorf("no arguments provided; task ID or --all flag required")
+			errNoArgs := fmt.Errorf("no arguments provided; task ID or --all flag required")
+			if flags.priority || flags.normal || flags.complete || flags.open {
+				return errNoArgs
+			}
+			if args, err = pickTasks(store, anyTask, "remove", true, errNoArgs); err != nil {
+				return err
+			}
 		}
 
 		// disallow mixed usage
@@ -113,21 +137,28 @@ When combining constraints, such as --priority and --complete, it will only remo
 				return fmt.Errorf("constraint flags require --all")
 			}
 
-			// get tasks
-			tasks, err := task.GetTasks()
+			// get tasks that comply with filters
+			tasks, err := store.List(task.Filter{
+				Complete: flags.complete,
+				Open:     flags.open,
+				Priority: flags.priority,
+				Normal:   flags.normal,
+			})
 			if err != nil {
 				return fmt.Errorf("failed to retrieve tasks: %w", err)
 			}
 
-			// backup database
-			if err := task.BackupDB(); err != nil {
-				fmt.Printf("Warning: failed to back up database: %v", err)
-			}
-
 			// prompt for confirmation
-			if !util.ConfirmAction("Confirm Removal?") {
+			if err := confirm("Confirm Removal?"); err != nil {
 				cmd.SilenceUsage = true
-				return fmt.Errorf("aborted by user")
+				return err
+			}
+
+			// backup database, unless there is nothing to change
+			if len(tasks) > 0 {
+				if err := store.Backup(); err != nil {
+					fmt.Printf("Warning: failed to back up database: %v", err)
+				}
 			}
 
 			// create list of task IDs that have been removed
@@ -139,22 +170,8 @@ When combining constraints, such as --priority and --complete, it will only remo
 			// loop through all tasks
 			for _, t := range tasks {
 
-				// check task complies with filters
-				if flags.priority && !t.Priority {
-					continue
-				}
-				if flags.normal && t.Priority {
-					continue
-				}
-				if flags.complete && !t.Complete {
-					continue
-				}
-				if flags.open && t.Complete {
-					continue
-				}
-
 				// remove task
-				if err := task.RemoveTask(t.ID); err != nil {
+				if err := store.Delete(t.ID); err != nil {
 					// add error message to hashmap, with error ID as key value
 					failed[t.ID] = err.Error()
 				} else {
@@ -200,41 +217,27 @@ When combining constraints, such as --priority and --complete, it will only remo
 
 		// argument given, remove by task IDs
 
-		// backup database
-		if err := task.BackupDB(); err != nil {
-			return fmt.Errorf("failed to back up database: %w", err)
-		}
-
-		// prompt for confirmation
-		if !util.ConfirmAction("Confirm Removal?") {
-			cmd.SilenceUsage = true
-			return fmt.Errorf("aborted by user")
-		}
-
 		// create list of task IDs that have been removed
 		var removedIDs []int
 
-		// create hashmap that stores task ID as string, and its error message, if task fails
-		failed := make(map[string]string)
-
-		// loop through all args input
-		for _, arg := range args {
+		// resolve IDs, ranges and selectors, keeping those that fail with their error message
+		ids, failed, err := selectTasks(store, args, "remove", true)
+		if err != nil {
+			return err
+		}
 
-			// parse args into int, add args to failed if needed
-			id, err := strconv.Atoi(arg)
-			if err != nil {
-				failed[arg] = "invalid task ID"
-				continue
+		// backup database, unless there is nothing to remove
+		if len(ids) > 0 {
+			if err := store.Backup(); err != nil {
+				return fmt.Errorf("failed to back up database: %w", err)
 			}
+		}
 
-			// check task exists, add task to failed if task does not exist
-			if err := task.CheckTaskExists(id); err != nil {
-				failed[strconv.Itoa(id)] = err.Error()
-				continue
-			}
+		// loop through selected tasks
+		for _, id := range ids {
 
 			// remove task, adding to failed if needed
-			if err := task.RemoveTask(id); err != nil {
+			if err := store.Delete(id); err != nil {
 				failed[strconv.Itoa(id)] = err.Error()
 			} else {
 				// removal successful, append ID to removed list
@@ -297,5 +300,8 @@ func init() {
 	removeCmd.Flags().BoolP("open", "o", false,
 		"Constrain --all to only remove open (incomplete) tasks")
 
+	// suggest task IDs
+	removeCmd.ValidArgsFunction = completeTaskIDs(anyTask)
+
 	rootCmd.AddCommand(removeCmd)
 }
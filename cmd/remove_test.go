@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/tm-craggs/tidytask/task"
+)
+
+func TestRemove(t *testing.T) {
+	store := newStore(t, task.Task{Title: "One"}, task.Task{Title: "Two"}, task.Task{Title: "Three"})
+
+	out := mustRun(t, store, "remove", "2", "--yes")
+	assertContains(t, out, "Removed task: 2")
+	if ids := taskIDs(t, store); !slices.Equal(ids, []int{1, 3}) {
+		t.Errorf("task IDs = %v, want [1 3]", ids)
+	}
+}
+
+func TestRemoveNeedsConfirmation(t *testing.T) {
+	store := newStore(t, task.Task{Title: "One"})
+
+	// stdin is not a terminal, so removal is refused without --yes
+	if _, err := run(t, store, "remove", "1"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if ids := taskIDs(t, store); !slices.Equal(ids, []int{1}) {
+		t.Errorf("task IDs = %v, want [1]", ids)
+	}
+}
+
+func TestRemoveAllComplete(t *testing.T) {
+	store := newStore(t,
+		task.Task{Title: "Open"},
+		task.Task{Title: "Done", Status: task.StatusDone},
+		task.Task{Title: "Also done", Status: task.StatusDone},
+	)
+
+	out := mustRun(t, store, "remove", "--all", "--complete", "--yes")
+	assertContains(t, out, "Removed tasks: 2, 3")
+	if ids := taskIDs(t, store); !slices.Equal(ids, []int{1}) {
+		t.Errorf("task IDs = %v, want [1]", ids)
+	}
+}
+
+func TestRemoveFlagErrors(t *testing.T) {
+	store := newStore(t, task.Task{Title: "One"})
+	for _, args := range [][]string{
+		{"remove", "1", "--all"},
+		{"remove", "--priority"},
+		{"remove", "--all", "--complete", "--open"},
+	} {
+		if _, err := run(t, store, append(args, "--yes")...); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+	if ids := taskIDs(t, store); len(ids) != 1 {
+		t.Errorf("task IDs = %v, want [1]", ids)
+	}
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/tm-craggs/tidytask/task"
+	"slices"
+)
+
+// renumberCmd represents the renumber subcommand
+var renumberCmd = &cobra.Command{
+	Use:   "renumber",
+	Short: "Compact task IDs into 1, 2, 3...",
+	Long: `The 'renumber' command gives tasks new IDs with no gaps, so after removing tasks your IDs start at 1 again.
+
+Open tasks are numbered first, in their current order, and closed tasks follow them. Use --all to number every
+task in its current order, whether open or closed. The ID of each task that changes is shown as old → new.
+
+Task handles do not change, so they still refer to the same tasks. Use 'undo' to restore the old IDs.`,
+
+	Example: `  tidytask renumber
+  > Number open tasks 1 to N, followed by closed tasks
+
+  tidytask renumber --all
+  > Number every task 1 to N, keeping their current order`,
+
+	// command logic
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		// check args
+		if len(args) > 0 {
+			return fmt.Errorf("unexpected arguments: %v; use --help for usage information", args)
+		}
+
+		// get store
+		store, err := getStore(cmd)
+		if err != nil {
+			return err
+		}
+
+		all, err := cmd.Flags().GetBool("all")
+		if err != nil {
+			return fmt.Errorf("failed to parse --all flag: %w", err)
+		}
+
+		// work out which IDs would change, from every task in ID order
+		tasks, err := store.List(task.Filter{})
+		if err != nil {
+			return fmt.Errorf("failed to retrieve tasks: %w", err)
+		}
+		slices.SortFunc(tasks, func(a, b task.Task) int { return a.ID - b.ID })
+
+		// leave the backup alone when there is nothing to change
+		if len(task.Renumbering(tasks, all)) == 0 {
+			fmt.Println("Task IDs are already in order. Nothing to renumber.")
+			return nil
+		}
+
+		// backup database
+		if err := store.Backup(); err != nil {
+			fmt.Printf("Warning: failed to back up database: %v", err)
+		}
+
+		// renumber every task together
+		changed, err := store.Renumber(all)
+		if err != nil {
+			return fmt.Errorf("failed to renumber tasks, no IDs changed: %w", err)
+		}
+
+		// show each change, by old ID
+		old := make([]int, 0, len(changed))
+		for id := range changed {
+			old = append(old, id)
+		}
+		slices.Sort(old)
+
+		label := "tasks"
+		if len(changed) == 1 {
+			label = "task"
+		}
+		fmt.Printf("Renumbered %d %s:\n", len(changed), label)
+		for _, id := range old {
+			fmt.Printf("  %d → %d\n", id, changed[id])
+		}
+
+		// exit
+		return nil
+	},
+}
+
+// command initialisation
+func init() {
+
+	// define flags and add subcommand to root
+	renumberCmd.Flags().BoolP("all", "a", false, "Number every task in its current order, not open tasks first")
+	rootCmd.AddCommand(renumberCmd)
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/tm-craggs/tidytask/task"
+)
+
+func TestRenumber(t *testing.T) {
+	store := newStore(t,
+		task.Task{Title: "One"},
+		task.Task{Title: "Two", Status: task.StatusDone},
+		task.Task{Title: "Three"},
+		task.Task{Title: "Four"},
+	)
+	mustRun(t, store, "remove", "1", "--yes")
+
+	// open tasks are numbered first, then closed tasks
+	out := mustRun(t, store, "renumber")
+	assertContains(t, out, "Renumbered 3 tasks:", "2 → 3", "3 → 1", "4 → 2")
+	for id, title := range map[int]string{1: "Three", 2: "Four", 3: "Two"} {
+		if got := getTask(t, store, id).Title; got != title {
+			t.Errorf("task %d is %q, want %q", id, got, title)
+		}
+	}
+
+	// undo restores the old IDs
+	mustRun(t, store, "undo", "--yes")
+	if ids := taskIDs(t, store); !slices.Equal(ids, []int{3, 4, 2}) {
+		t.Errorf("task IDs after undo = %v, want [3 4 2]", ids)
+	}
+
+	mustRun(t, store, "renumber")
+	out = mustRun(t, store, "renumber")
+	assertContains(t, out, "Task IDs are already in order. Nothing to renumber.")
+}
+
+func TestRenumberAll(t *testing.T) {
+	store := newStore(t,
+		task.Task{Title: "One"},
+		task.Task{Title: "Two", Status: task.StatusDone},
+		task.Task{Title: "Three"},
+	)
+	mustRun(t, store, "remove", "1", "--yes")
+
+	out := mustRun(t, store, "renumber", "--all")
+	assertContains(t, out, "Renumbered 2 tasks:", "2 → 1", "3 → 2")
+	for id, title := range map[int]string{1: "Two", 2: "Three"} {
+		if got := getTask(t, store, id).Title; got != title {
+			t.Errorf("task %d is %q, want %q", id, got, title)
+		}
+	}
+
+	if _, err := run(t, store, "renumber", "1"); err == nil {
+		t.Error("expected an error for an argument")
+	}
+}
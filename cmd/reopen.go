@@ -45,31 +45,56 @@ You can reopen tasks in two ways:
 2. Batch completion using the --all flag and optionally applying constraints, such as --priority.
 This limits the scope of the reopen batch operation to tasks meeting the given criteria. 
 
-You must only use one method. Supplying task IDs together with the --all flag for batch completion causes an error.`,
+You must only use one method. Supplying task IDs together with the --all flag for batch completion causes an error.
+
+Instead of single IDs you can give ranges such as 3-8, lists such as 1,4,9, 'last' for the most recently
+added task, or selectors such as @overdue, @today, @this-week, @priority or @doing. When ranges or selectors
+are used, the selected tasks are shown and you are asked to confirm first.
+
+A task's handle, such as k3fa, can be used in place of its ID. Handles are shown by 'list --handles' and never
+change, even when IDs do.
+
+Run 'reopen' without task IDs in a terminal to choose tasks from a list: type to filter, press space to select
+tasks and enter to confirm.`,
 	Example: `  tidytask reopen 1
   > Reopen task 1
 
   tidytask reopen 1 2 3
   > Reopen tasks 1, 2 and 3
 
+  tidytask reopen last
+  > Reopen the most recently added task
+
   tidytask reopen --all
-  > Reopen all tasks
+  > Reopen all done and cancelled tasks
 
   tidytask reopen --all --priority
-  > Reopen all high priority tasks`,
+  > Reopen all done and cancelled high priority tasks`,
 
 	// main command logic
 	RunE: func(cmd *cobra.Command, args []string) error {
 
+		// get store
+		store, err := getStore(cmd)
+		if err != nil {
+			return err
+		}
+
 		// get flags
 		flags, err := getReopenFlags(cmd)
 		if err != nil {
 			return err
 		}
 
-		// disallow no input
+		// with no input, choose tasks interactively, which fails outside a terminal
 		if len(args) == 0 && !flags.all {
-			return fmt.Errorf("no arguments provided; task ID or --all flag required")
+			errNoArgs := fmt.Errorf("no arguments provided; task ID or --all flag required")
+			if flags.priority || flags.normal {
+				return errNoArgs
+			}
+			if args, err = pickTasks(store, closedTask, "reopen", true, errNoArgs); err != nil {
+				return err
+			}
 		}
 
 		// disallow mixed usage
@@ -77,21 +102,11 @@ You must only use one method. Supplying task IDs together with the --all flag fo
 			return fmt.Errorf("cannot use task IDs and batch operation flags together")
 		}
 
-		// check flags have not been used with task IDs
-		if len(args) > 0 && (flags.all || flags.priority || flags.normal) {
-			return fmt.Errorf("cannot use task IDs and batch operation flags together")
-		}
-
 		// check for flag conflicts
 		if flags.priority && flags.normal {
 			return fmt.Errorf("conflicting flags: cannot use --priority and --normal together")
 		}
 
-		// backup database
-		if err := task.BackupDB(); err != nil {
-			fmt.Printf("Warning: failed to back up database: %v", err)
-		}
-
 		// filter based removal
 		if len(args) == 0 {
 
@@ -100,12 +115,19 @@ You must only use one method. Supplying task IDs together with the --all flag fo
 				return fmt.Errorf("constraint flags require --all")
 			}
 
-			// get tasks
-			tasks, err := task.GetTasks()
+			// get closed tasks that comply with filters, open tasks have nothing to reopen
+			tasks, err := store.List(task.Filter{Closed: true, Priority: flags.priority, Normal: flags.normal})
 			if err != nil {
 				return fmt.Errorf("failed to retrieve tasks: %w", err)
 			}
 
+			// backup database, unless there is nothing to change
+			if len(tasks) > 0 {
+				if err := store.Backup(); err != nil {
+					fmt.Printf("Warning: failed to back up database: %v", err)
+				}
+			}
+
 			// create list of task IDs that have been reopened
 			var reopenIDs []int
 
@@ -115,16 +137,8 @@ You must only use one method. Supplying task IDs together with the --all flag fo
 			// loop through all tasks
 			for _, t := range tasks {
 
-				// check task complies with filters
-				if flags.priority && !t.Priority {
-					continue
-				}
-				if flags.normal && t.Priority {
-					continue
-				}
-
 				// reopen task
-				if err := task.ReopenTask(t.ID); err != nil {
+				if err := store.Reopen(t.ID); err != nil {
 					// add error message to hashmap, with error ID as key value
 					failed[t.ID] = err.Error()
 				} else {
@@ -173,27 +187,24 @@ You must only use one method. Supplying task IDs together with the --all flag fo
 		// create list of task IDs that have been reopened
 		var reopenIDs []int
 
-		// create hashmap that stores task ID as string, and its error message, if task fails
-		failed := make(map[string]string)
-
-		// loop through all args input
-		for _, arg := range args {
+		// resolve IDs, ranges and selectors, keeping those that fail with their error message
+		ids, failed, err := selectTasks(store, args, "reopen", false)
+		if err != nil {
+			return err
+		}
 
-			// parse args into int, add args to failed if needed
-			id, err := strconv.Atoi(arg)
-			if err != nil {
-				failed[arg] = "invalid task ID"
-				continue
+		// backup database, unless there is nothing to change
+		if len(ids) > 0 {
+			if err := store.Backup(); err != nil {
+				fmt.Printf("Warning: failed to back up database: %v", err)
 			}
+		}
 
-			// check task exists, add task to failed if task does not exist
-			if err := task.CheckTaskExists(id); err != nil {
-				failed[strconv.Itoa(id)] = err.Error()
-				continue
-			}
+		// loop through selected tasks
+		for _, id := range ids {
 
 			// reopen task, adding to failed if needed
-			if err := task.ReopenTask(id); err != nil {
+			if err := store.Reopen(id); err != nil {
 				failed[strconv.Itoa(id)] = err.Error()
 			} else {
 				// removal successful, append ID to reopened list
@@ -242,7 +253,7 @@ func init() {
 	// define flags and add subcommand to root
 
 	reopenCmd.Flags().BoolP("all", "a", false,
-		"Reopen all tasks (can be combined with constraints)")
+		"Reopen all done and cancelled tasks (can be combined with constraints)")
 
 	reopenCmd.Flags().BoolP("priority", "p", false,
 		"Constrain --all to only reopen high priority tasks")
@@ -250,5 +261,8 @@ func init() {
 	reopenCmd.Flags().BoolP("normal", "n", false,
 		"Constrain --all to only reopen normal priority tasks")
 
+	// suggest closed task IDs
+	reopenCmd.ValidArgsFunction = completeTaskIDs(closedTask)
+
 	rootCmd.AddCommand(reopenCmd)
 }
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/tm-craggs/tidytask/task"
+)
+
+func TestReopen(t *testing.T) {
+	store := newStore(t,
+		task.Task{Title: "Done", Status: task.StatusDone},
+		task.Task{Title: "Cancelled", Status: task.StatusCancelled},
+		task.Task{Title: "Open"},
+	)
+
+	out := mustRun(t, store, "reopen", "1", "2")
+	assertContains(t, out, "Reopened tasks: 1, 2")
+
+	for _, id := range []int{1, 2} {
+		got := getTask(t, store, id)
+		if got.Complete || got.Status != task.StatusTodo || got.CompleteDate.Valid {
+			t.Errorf("task %d = %+v, want open with no completion date", id, got)
+		}
+	}
+}
+
+func TestReopenAll(t *testing.T) {
+	store := newStore(t,
+		task.Task{Title: "Done", Status: task.StatusDone},
+		task.Task{Title: "Done priority", Priority: true, Status: task.StatusDone},
+	)
+
+	mustRun(t, store, "reopen", "--all", "--normal")
+	if getTask(t, store, 1).Complete != false || getTask(t, store, 2).Complete != true {
+		t.Error("only the normal priority task should be reopened")
+	}
+}
+
+func TestReopenAllSkipsOpenTasks(t *testing.T) {
+	store := newStore(t, task.Task{Title: "Done", Status: task.StatusDone}, task.Task{Title: "Doing", Status: task.StatusDoing})
+	doing := getTask(t, store, 2)
+
+	out := mustRun(t, store, "reopen", "--all")
+	assertContains(t, out, "Reopened task: 1")
+	if got := getTask(t, store, 2); got.Status != task.StatusDoing || !got.UpdatedAt.Equal(doing.UpdatedAt) {
+		t.Errorf("open task changed from %+v to %+v", doing, got)
+	}
+}
+
+func TestReopenMissingTask(t *testing.T) {
+	store := newStore(t)
+	if _, err := run(t, store, "reopen", "4"); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -0,0 +1,290 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/tm-craggs/tidytask/task"
+	"github.com/tm-craggs/tidytask/util"
+	"time"
+)
+
+// Periods accepted by --by
+const (
+	periodDay  = "day"
+	periodWeek = "week"
+)
+
+// create struct that defines the available flags for report commands
+type reportFlags struct {
+	since time.Time
+	by    string
+}
+
+// helper function to parse flags with error handling.
+// --since defaults to sinceDay or sinceWeek before today, depending on --by
+func getReportFlags(cmd *cobra.Command, sinceDay, sinceWeek string) (reportFlags, error) {
+	var flags reportFlags
+	var err error
+
+	if flags.by, err = cmd.Flags().GetString("by"); err != nil {
+		return flags, fmt.Errorf("failed to parse --by flag: %w", err)
+	}
+	if flags.by != periodDay && flags.by != periodWeek {
+		return flags, fmt.Errorf("invalid --by value %q; use %s or %s", flags.by, periodDay, periodWeek)
+	}
+
+	since, err := cmd.Flags().GetString("since")
+	if err != nil {
+		return flags, fmt.Errorf("failed to parse --since flag: %w", err)
+	}
+	if since == "" {
+		since = sinceDay
+		if flags.by == periodWeek {
+			since = sinceWeek
+		}
+	}
+	if flags.since, err = parseSince(since); err != nil {
+		return flags, err
+	}
+
+	return flags, nil
+}
+
+// parseSince parses the start of a report, either a date or a period before today such as 4w
+func parseSince(spec string) (time.Time, error) {
+	if date, err := time.ParseInLocation("2006-01-02", spec, time.Local); err == nil {
+		if date.After(util.Today()) {
+			return time.Time{}, fmt.Errorf("invalid --since value %q; date must not be in the future", spec)
+		}
+		return date, nil
+	}
+
+	period, err := util.ParsePeriod(spec)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since value %q; use a date (YYYY-MM-DD) or a period such as 4w", spec)
+	}
+	return util.Today().AddDate(0, 0, -int(period.Hours()/24)), nil
+}
+
+// reportPeriods returns the start of each day or week from since until today.
+// weeks start on a Monday, so the first week may start before since
+func reportPeriods(since time.Time, by string) []time.Time {
+	step := 1
+	if by == periodWeek {
+		since = util.StartOfWeek(since)
+		step = 7
+	}
+
+	var periods []time.Time
+	for day := since; !day.After(util.Today()); day = day.AddDate(0, 0, step) {
+		periods = append(periods, day)
+	}
+	return periods
+}
+
+// periodLabels formats the start of each period as a date
+func periodLabels(periods []time.Time) []string {
+	labels := make([]string, len(periods))
+	for i, p := range periods {
+		labels[i] = p.Format("2006-01-02")
+	}
+	return labels
+}
+
+// closedOn returns the date a task was closed, or "" for open tasks.
+// no date is stored when a task is cancelled, so the time it was last changed is used instead
+func closedOn(t task.Task) string {
+	switch {
+	case t.Complete:
+		return t.CompleteDate.String
+	case t.Status == task.StatusCancelled:
+		return t.UpdatedAt.Local().Format("2006-01-02")
+	default:
+		return ""
+	}
+}
+
+// reportCmd represents the report command
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Draw charts of your progress",
+	Long: `The 'report' command draws charts in the terminal from when tasks were added and completed.
+
+Use 'report burndown' to see how many tasks were open over time, and 'report velocity' to see how many tasks
+were completed each day or week.`,
+
+	Example: `  tidytask report burndown --since 2025-06-01
+  > Chart the number of open tasks each day since 1 June 2025
+
+  tidytask report velocity
+  > Chart the number of tasks completed each week`,
+}
+
+// reportBurndownCmd represents the report burndown subcommand
+var reportBurndownCmd = &cobra.Command{
+	Use:   "burndown",
+	Short: "Chart the number of open tasks over time",
+	Long: `The 'report burndown' command draws a line chart of the number of tasks that were open at the end of each
+day or week, from --since until today.
+
+--since takes a date or a period before today, such as 4w, and defaults to 4 weeks. Use --by week to show
+one point per week. Tasks that were removed are not counted, and cancelled tasks count as closed from the
+last time they were changed.`,
+
+	Example: `  tidytask report burndown
+  > Chart the number of open tasks each day over the last 4 weeks
+
+  tidytask report burndown --since 2025-06-01 --by week
+  > Chart the number of open tasks each week since 1 June 2025`,
+
+	// main command logic
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		// check args
+		if len(args) > 0 {
+			return fmt.Errorf("unexpected arguments: %v; use --help for usage information", args)
+		}
+
+		// get flags
+		flags, err := getReportFlags(cmd, "4w", "4w")
+		if err != nil {
+			return err
+		}
+
+		// get store and every task
+		store, err := getStore(cmd)
+		if err != nil {
+			return err
+		}
+		tasks, err := store.List(task.Filter{})
+		if err != nil {
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+
+		// count the tasks open at the end of each period, or today for the current one
+		periods := reportPeriods(flags.since, flags.by)
+		open := make([]int, len(periods))
+		for i, start := range periods {
+			end := start
+			if flags.by == periodWeek {
+				end = start.AddDate(0, 0, 6)
+			}
+			if end.After(util.Today()) {
+				end = util.Today()
+			}
+			last := end.Format("2006-01-02")
+
+			for _, t := range tasks {
+				created := t.CreatedAt.Local().Format("2006-01-02")
+				closed := closedOn(t)
+				if created <= last && (closed == "" || closed > last) {
+					open[i]++
+				}
+			}
+		}
+
+		// print chart
+		fmt.Printf("Open tasks by %s:\n\n", flags.by)
+		for _, line := range util.LineChart(periodLabels(periods), open, 10) {
+			fmt.Println(line)
+		}
+		fmt.Printf("\n%d open on %s, %d open now (%+d)\n",
+			open[0], periods[0].Format("2006-01-02"), open[len(open)-1], open[len(open)-1]-open[0])
+
+		// exit
+		return nil
+	},
+}
+
+// reportVelocityCmd represents the report velocity subcommand
+var reportVelocityCmd = &cobra.Command{
+	Use:   "velocity",
+	Short: "Chart the number of tasks completed over time",
+	Long: `The 'report velocity' command draws a bar chart of the number of tasks completed each day or week, from
+--since until today, and the average per day or week.
+
+Weeks run from Monday to Sunday, and the current day or week may not have finished. --since takes a date or a
+period before today, such as 4w, and defaults to 8 weeks, or 2 weeks with --by day.`,
+
+	Example: `  tidytask report velocity
+  > Chart the number of tasks completed each week over the last 8 weeks
+
+  tidytask report velocity --by day --since 2025-06-01
+  > Chart the number of tasks completed each day since 1 June 2025`,
+
+	// main command logic
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		// check args
+		if len(args) > 0 {
+			return fmt.Errorf("unexpected arguments: %v; use --help for usage information", args)
+		}
+
+		// get flags
+		flags, err := getReportFlags(cmd, "2w", "8w")
+		if err != nil {
+			return err
+		}
+
+		// get store and completed tasks
+		store, err := getStore(cmd)
+		if err != nil {
+			return err
+		}
+		tasks, err := store.List(task.Filter{Complete: true})
+		if err != nil {
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+
+		// count the tasks completed in each period
+		periods := reportPeriods(flags.since, flags.by)
+		labels := periodLabels(periods)
+		completed := make([]int, len(periods))
+		total := 0
+		for _, t := range tasks {
+			if !t.CompleteDate.Valid {
+				continue
+			}
+
+			// find the last period starting on or before the completion date
+			for i := len(labels) - 1; i >= 0; i-- {
+				if labels[i] <= t.CompleteDate.String {
+					completed[i]++
+					total++
+					break
+				}
+			}
+		}
+
+		// print chart
+		fmt.Printf("Tasks completed by %s:\n\n", flags.by)
+		for _, line := range util.BarChart(labels, completed, 40) {
+			fmt.Println(line)
+		}
+		fmt.Printf("\n%d completed, an average of %.1f per %s\n", total, float64(total)/float64(len(periods)), flags.by)
+
+		// exit
+		return nil
+	},
+}
+
+// command initialisation
+func init() {
+
+	// define flags
+	for _, c := range []*cobra.Command{reportBurndownCmd, reportVelocityCmd} {
+		c.Flags().StringP("since", "s", "", "Start date (YYYY-MM-DD) or period before today, such as 4w")
+		_ = c.RegisterFlagCompletionFunc("since", cobra.NoFileCompletions)
+	}
+	reportBurndownCmd.Flags().String("by", periodDay, "Show one point per day or week")
+	reportVelocityCmd.Flags().String("by", periodWeek, "Count completed tasks per day or week")
+	for _, c := range []*cobra.Command{reportBurndownCmd, reportVelocityCmd} {
+		_ = c.RegisterFlagCompletionFunc("by", cobra.FixedCompletions(
+			[]string{periodDay, periodWeek}, cobra.ShellCompDirectiveNoFileComp))
+	}
+
+	// add subcommands to report, and report to root
+	reportCmd.AddCommand(reportBurndownCmd)
+	reportCmd.AddCommand(reportVelocityCmd)
+	rootCmd.AddCommand(reportCmd)
+}
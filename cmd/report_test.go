@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tm-craggs/tidytask/task"
+	"github.com/tm-craggs/tidytask/util"
+)
+
+func TestParseSince(t *testing.T) {
+	today := util.Today()
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"2025-06-01", time.Date(2025, 6, 1, 0, 0, 0, 0, time.Local)},
+		{today.Format("2006-01-02"), today},
+		{"0d", today},
+		{"3d", today.AddDate(0, 0, -3)},
+		{"4w", today.AddDate(0, 0, -28)},
+	}
+	for _, tt := range tests {
+		got, err := parseSince(tt.spec)
+		if err != nil {
+			t.Errorf("parseSince(%q) error: %v", tt.spec, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseSince(%q) = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+
+	for _, spec := range []string{today.AddDate(0, 0, 1).Format("2006-01-02"), "4", "last week", "2025-13-01"} {
+		if _, err := parseSince(spec); err == nil {
+			t.Errorf("parseSince(%q) did not return an error", spec)
+		}
+	}
+}
+
+func TestReportPeriods(t *testing.T) {
+	today := util.Today()
+
+	days := reportPeriods(today.AddDate(0, 0, -2), periodDay)
+	if want := []time.Time{today.AddDate(0, 0, -2), today.AddDate(0, 0, -1), today}; !reflect.DeepEqual(days, want) {
+		t.Errorf("days = %v, want %v", days, want)
+	}
+
+	// weeks start on the Monday on or before since, and the last week is the current one
+	weeks := reportPeriods(today.AddDate(0, 0, -10), periodWeek)
+	if len(weeks) < 2 || len(weeks) > 3 {
+		t.Fatalf("got %d weeks, want 2 or 3", len(weeks))
+	}
+	for i, w := range weeks {
+		if w.Weekday() != time.Monday {
+			t.Errorf("week %d starts on %s", i, w.Weekday())
+		}
+	}
+	if first := weeks[0]; first.After(today.AddDate(0, 0, -10)) || !first.After(today.AddDate(0, 0, -17)) {
+		t.Errorf("first week starts %v", first)
+	}
+	if last := weeks[len(weeks)-1]; !last.Equal(util.StartOfWeek(today)) {
+		t.Errorf("last week starts %v, want %v", last, util.StartOfWeek(today))
+	}
+
+	labels := periodLabels([]time.Time{time.Date(2025, 6, 2, 0, 0, 0, 0, time.Local)})
+	if !reflect.DeepEqual(labels, []string{"2025-06-02"}) {
+		t.Errorf("labels = %v", labels)
+	}
+}
+
+func TestClosedOn(t *testing.T) {
+	updated := time.Date(2025, 6, 3, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		t    task.Task
+		want string
+	}{
+		{task.Task{Status: task.StatusTodo, UpdatedAt: updated}, ""},
+		{task.Task{Status: task.StatusDoing, UpdatedAt: updated}, ""},
+		{task.Task{Status: task.StatusDone, Complete: true, CompleteDate: sql.NullString{String: "2025-06-01", Valid: true}, UpdatedAt: updated}, "2025-06-01"},
+		{task.Task{Status: task.StatusCancelled, UpdatedAt: updated}, "2025-06-03"},
+	}
+	for _, tt := range tests {
+		if got := closedOn(tt.t); got != tt.want {
+			t.Errorf("closedOn(%s) = %q, want %q", tt.t.Status, got, tt.want)
+		}
+	}
+}
+
+func TestReportBurndown(t *testing.T) {
+	today := util.Today()
+	store := newStore(t,
+		task.Task{Title: "Open"},
+		task.Task{Title: "Doing", Status: task.StatusDoing},
+		task.Task{Title: "Done", Status: task.StatusDone},
+		task.Task{Title: "Cancelled", Status: task.StatusCancelled},
+	)
+
+	// every task was added today, so none were open before today
+	out := mustRun(t, store, "report", "burndown", "--since", "3d")
+	since := today.AddDate(0, 0, -3).Format("2006-01-02")
+	assertContains(t, out, "Open tasks by day:", "0 open on "+since+", 2 open now (+2)")
+
+	out = mustRun(t, store, "report", "burndown", "--by", "week")
+	assertContains(t, out, "Open tasks by week:", "2 open now")
+}
+
+func TestReportVelocity(t *testing.T) {
+	today := util.Today().Format("2006-01-02")
+	store := newStore(t,
+		task.Task{Title: "Open"},
+		task.Task{Title: "Done", Status: task.StatusDone},
+		task.Task{Title: "Also done", Status: task.StatusDone},
+	)
+
+	out := mustRun(t, store, "report", "velocity", "--by", "day", "--since", today)
+	assertContains(t, out, "Tasks completed by day:", "2 completed, an average of 2.0 per day")
+
+	out = mustRun(t, store, "report", "velocity", "--since", "0d")
+	assertContains(t, out, "Tasks completed by week:", "2 completed, an average of 2.0 per week")
+}
+
+func TestReportErrors(t *testing.T) {
+	store := newStore(t, task.Task{Title: "One"})
+	future := util.Today().AddDate(0, 0, 1).Format("2006-01-02")
+	for _, args := range [][]string{
+		{"report", "burndown", "extra"},
+		{"report", "burndown", "--by", "month"},
+		{"report", "velocity", "--since", future},
+		{"report", "velocity", "--since", "soon"},
+	} {
+		if _, err := run(t, store, args...); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+}
@@ -3,8 +3,6 @@ package cmd
 import (
 	"fmt"
 	"github.com/spf13/cobra"
-	"github.com/tm-craggs/tidytask/task"
-	"github.com/tm-craggs/tidytask/util"
 )
 
 var resetCmd = &cobra.Command{
@@ -14,7 +12,8 @@ var resetCmd = &cobra.Command{
 	Long: `The 'reset' command hard resets TidyTask by deleting the file which stores your tasks, and the backup file
 if they exist. New files are created on next launch. 
 
-This is useful to recover from corrupted files, or to restart task ID numbering at 1
+This is useful to recover from corrupted files. To restart task ID numbering at 1 without losing any tasks,
+use 'renumber' instead.
 
 WARNING: This will delete all task data and cannot be undone.`,
 
@@ -27,8 +26,8 @@ WARNING: This will delete all task data and cannot be undone.`,
 		}
 
 		fmt.Println("WARNING: This will delete all task data and cannot be undone.")
-		if !util.ConfirmAction("Confirm hard reset?") {
-			return fmt.Errorf("aborted by user")
+		if err := confirm("Confirm hard reset?"); err != nil {
+			return err
 		}
 		return nil
 	},
@@ -36,9 +35,14 @@ WARNING: This will delete all task data and cannot be undone.`,
 	// command logic
 	RunE: func(cmd *cobra.Command, args []string) error {
 
-		// attempt to restore backup, throw error if no backup file found
-		err := task.HardReset()
+		// get store
+		store, err := getStore(cmd)
 		if err != nil {
+			return err
+		}
+
+		// delete the database and backup
+		if err := store.Reset(); err != nil {
 			return fmt.Errorf("failed to hard reset: %w", err)
 		}
 
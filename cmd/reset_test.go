@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/tm-craggs/tidytask/task"
+)
+
+func TestReset(t *testing.T) {
+	store := newStore(t, task.Task{Title: "One"}, task.Task{Title: "Two"})
+
+	out := mustRun(t, store, "reset", "--yes")
+	assertContains(t, out, "WARNING", "TidyTask reset")
+	if ids := taskIDs(t, store); len(ids) != 0 {
+		t.Errorf("task IDs after reset = %v, want none", ids)
+	}
+
+	// IDs restart at 1, and there is nothing to undo
+	mustRun(t, store, "add", "Fresh")
+	getTask(t, store, 1)
+	if _, err := run(t, store, "undo", "--yes"); err != nil {
+		t.Errorf("undo after add: %v", err)
+	}
+	if _, err := run(t, store, "undo", "--yes"); err == nil {
+		t.Error("expected an error with no backup")
+	}
+}
+
+func TestResetNeedsConfirmation(t *testing.T) {
+	store := newStore(t, task.Task{Title: "One"})
+	if _, err := run(t, store, "reset"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if ids := taskIDs(t, store); len(ids) != 1 {
+		t.Errorf("task IDs = %v, want [1]", ids)
+	}
+}
@@ -7,11 +7,34 @@ Copyright © 2025 Thomas Craggs <tom.craggs@protonmail.com>
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/spf13/cobra"
+	"github.com/tm-craggs/tidytask/config"
+	"github.com/tm-craggs/tidytask/selector"
 	"github.com/tm-craggs/tidytask/task"
+	"github.com/tm-craggs/tidytask/tui"
+	"github.com/tm-craggs/tidytask/util"
+	"slices"
+	"strings"
+)
+
+// annotationNoDB marks commands that should not open the database before running
+const annotationNoDB = "tidytask/no-db"
+
+var (
+	// cfg holds the user's settings, loaded before each command runs
+	cfg config.Config
+
+	// dbScope records whether the active database is custom, project-local or global
+	dbScope string
+
+	// openedStore is the store opened by the root command, closed after the command runs.
+	// it stays nil when a store was injected through the command context
+	openedStore task.Store
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -34,19 +57,193 @@ A simple CLI tool for managing your to-do-list. Built with Go`,
 	// has an action associated with it:
 	// Run: func(cmd *cobra.Command, args []string) { },
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		if err := task.InitDB(); err != nil {
+
+		// check whether prompts can be shown
+		if err := readInputFlags(cmd); err != nil {
+			return err
+		}
+
+		var err error
+		if dryRun, err = cmd.Flags().GetBool("dry-run"); err != nil {
+			return fmt.Errorf("failed to parse --dry-run flag: %w", err)
+		}
+
+		// load settings
+		if err := loadConfig(); err != nil {
+			return err
+		}
+
+		// some commands, such as init, manage the database themselves
+		if cmd.Annotations[annotationNoDB] == "true" {
+			return nil
+		}
+
+		// use a store provided by the caller, such as a MemoryStore in tests
+		if store, ok := task.FromContext(cmd.Context()); ok {
+			return startDryRun(cmd, store)
+		}
+
+		global, err := cmd.Flags().GetBool("global")
+		if err != nil {
+			return fmt.Errorf("failed to parse --global flag: %w", err)
+		}
+
+		explicit, err := cmd.Flags().GetString("db")
+		if err != nil {
+			return fmt.Errorf("failed to parse --db flag: %w", err)
+		}
+
+		// use --db or TIDYTASK_DB if set, otherwise prefer a project-local database unless --global is set
+		var path string
+		path, dbScope, err = task.ResolveDBPath(explicit, global)
+		if err != nil {
+			return fmt.Errorf("failed to locate database: %w", err)
+		}
+
+		// open the database and hand it to the command through its context
+		store, err := task.Open(path)
+		if err != nil {
 			return fmt.Errorf("DB creation error: %w", err)
 		}
-		return nil
+		openedStore = store
+		cmd.SetContext(task.NewContext(cmd.Context(), store))
+
+		// plan changes in a copy of the tasks for --dry-run
+		return startDryRun(cmd, store)
 	},
 	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
-		if err := task.CloseDB(); err != nil {
+		if err := printDryRun(); err != nil {
+			return err
+		}
+		if openedStore == nil {
+			return nil
+		}
+		if err := openedStore.Close(); err != nil {
 			return fmt.Errorf("DB closing error: %v\n", err)
 		}
 		return nil
 	},
 }
 
+// loadConfig reads the config file into cfg and registers user-defined statuses for display
+func loadConfig() error {
+	var err error
+	if cfg, err = config.Load(); err != nil {
+		return err
+	}
+
+	for _, s := range cfg.Statuses {
+		if s.Name == "" || strings.ContainsAny(s.Name, " \t") {
+			return fmt.Errorf("invalid status name %q in config; names must be a single word", s.Name)
+		}
+		if slices.Contains(task.BuiltinStatuses, s.Name) {
+			return fmt.Errorf("status %q in config is built in; choose another name", s.Name)
+		}
+		util.RegisterStatus(s.Name, s.Color)
+	}
+
+	return nil
+}
+
+// knownStatuses returns the built-in statuses followed by the user-defined ones
+func knownStatuses() []string {
+	statuses := slices.Clone(task.BuiltinStatuses)
+	for _, s := range cfg.Statuses {
+		statuses = append(statuses, s.Name)
+	}
+	return statuses
+}
+
+// checkStatus returns an error if status is neither built in nor defined in the config
+func checkStatus(status string) error {
+	if !slices.Contains(knownStatuses(), status) {
+		return fmt.Errorf("unknown status %q; use one of %s", status, strings.Join(knownStatuses(), ", "))
+	}
+	return nil
+}
+
+// getStore returns the store the command should operate on, provided through the command context
+func getStore(cmd *cobra.Command) (task.Store, error) {
+	store, ok := task.FromContext(cmd.Context())
+	if !ok {
+		return nil, fmt.Errorf("no database open")
+	}
+	return store, nil
+}
+
+// selectTasks resolves the task IDs, ranges and selectors in args, returning the selected IDs and a map of
+// selectors that failed with their error messages. when args are not all plain IDs, the selected tasks are
+// shown before asking the user to confirm. set mustConfirm to always ask, such as before removing tasks
+func selectTasks(store task.Store, args []string, verb string, mustConfirm bool) ([]int, map[string]string, error) {
+	result, err := selector.Resolve(store, args, knownStatuses())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// nothing to confirm if no tasks were selected
+	if len(result.Tasks) == 0 {
+		return nil, result.Failed, nil
+	}
+
+	// show the tasks chosen by ranges and selectors, as they may not be the ones expected
+	plain := selector.IsPlain(args)
+	if !plain {
+		fmt.Printf("Selected tasks to %s:\n", verb)
+		if err := util.PrintTasks(result.Tasks, util.PrintOptions{}); err != nil {
+			return nil, nil, fmt.Errorf("failed to print tasks: %w", err)
+		}
+	}
+
+	// prompt for confirmation
+	if mustConfirm || !plain {
+		label := "tasks"
+		if len(result.Tasks) == 1 {
+			label = "task"
+		}
+		if err := confirm(fmt.Sprintf("Confirm %s %d %s?", verb, len(result.Tasks), label)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return result.IDs(), result.Failed, nil
+}
+
+// pickTasks lets the user choose from the tasks chosen by match when no task IDs are given, returning the chosen
+// IDs as arguments for selectTasks. multi allows several tasks to be chosen.
+// outside a terminal nothing can be picked, so errNoArgs is returned and scripts fail instead of waiting for input
+func pickTasks(store task.Store, match func(task.Task) bool, verb string, multi bool, errNoArgs error) ([]string, error) {
+	if noInput || !tui.IsTerminal() {
+		return nil, errNoArgs
+	}
+
+	all, err := store.List(task.Filter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tasks: %w", err)
+	}
+	tasks := slices.DeleteFunc(all, func(t task.Task) bool { return !match(t) })
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("no tasks to %s", verb)
+	}
+
+	title := fmt.Sprintf("Choose a task to %s", verb)
+	if multi {
+		title = fmt.Sprintf("Choose tasks to %s", verb)
+	}
+	ids, err := tui.Pick(tasks, title, multi)
+	switch {
+	case errors.Is(err, tui.ErrCancelled):
+		return nil, fmt.Errorf("aborted by user")
+	case err != nil:
+		return nil, fmt.Errorf("failed to choose tasks: %w", err)
+	}
+
+	args := make([]string, len(ids))
+	for i, id := range ids {
+		args[i] = strconv.Itoa(id)
+	}
+	return args, nil
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
@@ -68,6 +265,11 @@ func init() {
 	// will be global for your application.
 
 	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.TidyTask.yaml)")
+	rootCmd.PersistentFlags().BoolP("global", "g", false, "Use your user database even inside a project with a local database")
+	rootCmd.PersistentFlags().String("db", "", `Path of the database to use, or ":memory:" for an ephemeral session (overrides $TIDYTASK_DB)`)
+	rootCmd.PersistentFlags().BoolP("yes", "y", false, "Answer yes to every confirmation (or set $TIDYTASK_ASSUME_YES)")
+	rootCmd.PersistentFlags().Bool("no-input", false, "Never prompt or open interactive pickers, fail instead")
+	rootCmd.PersistentFlags().Bool("dry-run", false, "Show the changes a command would make without saving them")
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
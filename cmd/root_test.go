@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/tm-craggs/tidytask/config"
+	"github.com/tm-craggs/tidytask/task"
+)
+
+// TestMain keeps the user's environment and config out of the tests, tests that need them set them themselves
+func TestMain(m *testing.M) {
+	_ = os.Unsetenv(envAssumeYes)
+	_ = os.Unsetenv(config.EnvConfig)
+	os.Exit(m.Run())
+}
+
+// run executes tidytask with args against store, returning what the command printed.
+// when store is nil the database is located and opened as it would be outside the tests.
+// stdin is an empty pipe rather than a terminal, so commands that would prompt refuse unless --yes is given
+func run(t *testing.T, store task.Store, args ...string) (string, error) {
+	t.Helper()
+
+	// use an empty config for the rest of the test, unless the test has written one with writeConfig
+	if os.Getenv(config.EnvConfig) == "" {
+		t.Setenv(config.EnvConfig, filepath.Join(t.TempDir(), "config.json"))
+	}
+
+	// commands and flags keep their state between runs, so start each run afresh
+	resetCommand(rootCmd)
+	dryRunStore = nil
+	openedStore = nil
+	rootCmd.SilenceUsage = true
+	rootCmd.SilenceErrors = true
+	rootCmd.SetArgs(args)
+
+	// replace stdin with an empty pipe
+	stdin, stdinWriter, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = stdinWriter.Close()
+	oldStdin := os.Stdin
+	os.Stdin = stdin
+	defer func() {
+		os.Stdin = oldStdin
+		_ = stdin.Close()
+	}()
+
+	// capture stdout, reading as the command writes so large output cannot fill the pipe
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldStdout := os.Stdout
+	os.Stdout = writer
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(reader)
+		output <- string(data)
+	}()
+
+	ctx := context.Background()
+	if store != nil {
+		ctx = task.NewContext(ctx, store)
+	}
+	err = rootCmd.ExecuteContext(ctx)
+
+	os.Stdout = oldStdout
+	_ = writer.Close()
+	return <-output, err
+}
+
+// writeConfig writes a config file holding data, and uses it for the rest of the test
+func writeConfig(t *testing.T, data string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(config.EnvConfig, path)
+}
+
+// mustRun is run for commands that are expected to succeed
+func mustRun(t *testing.T, store task.Store, args ...string) string {
+	t.Helper()
+	out, err := run(t, store, args...)
+	if err != nil {
+		t.Fatalf("tidytask %s: %v\noutput:\n%s", strings.Join(args, " "), err, out)
+	}
+	return out
+}
+
+// resetCommand returns every flag of c and its subcommands to its default, and clears their contexts
+func resetCommand(c *cobra.Command) {
+	c.SetContext(nil)
+
+	reset := func(f *pflag.Flag) {
+		if s, ok := f.Value.(pflag.SliceValue); ok {
+			_ = s.Replace(nil)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	c.Flags().VisitAll(reset)
+	c.PersistentFlags().VisitAll(reset)
+
+	for _, sub := range c.Commands() {
+		resetCommand(sub)
+	}
+}
+
+// newStore returns a MemoryStore holding tasks, which are given IDs from 1 in order
+func newStore(t *testing.T, tasks ...task.Task) *task.MemoryStore {
+	t.Helper()
+	store := task.NewMemoryStore()
+	for _, tk := range tasks {
+		status := tk.Status
+		id, err := store.Add(tk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status != "" && status != task.StatusTodo {
+			if err := store.SetStatus(id, status); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return store
+}
+
+// getTask returns the task with the given ID, failing the test if it does not exist
+func getTask(t *testing.T, store task.Store, id int) task.Task {
+	t.Helper()
+	tk, err := store.Get(id)
+	if err != nil {
+		t.Fatalf("get task %d: %v", id, err)
+	}
+	return tk
+}
+
+// taskIDs returns the IDs of every task in store, in display order
+func taskIDs(t *testing.T, store task.Store) []int {
+	t.Helper()
+	tasks, err := store.List(task.Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make([]int, len(tasks))
+	for i, tk := range tasks {
+		ids[i] = tk.ID
+	}
+	return ids
+}
+
+// assertContains fails the test unless out contains each of want
+func assertContains(t *testing.T, out string, want ...string) {
+	t.Helper()
+	for _, w := range want {
+		if !strings.Contains(out, w) {
+			t.Errorf("output does not contain %q:\n%s", w, out)
+		}
+	}
+}
+
+// assertNotContains fails the test if out contains any of unwanted
+func assertNotContains(t *testing.T, out string, unwanted ...string) {
+	t.Helper()
+	for _, w := range unwanted {
+		if strings.Contains(out, w) {
+			t.Errorf("output contains %q:\n%s", w, out)
+		}
+	}
+}
+
+func TestPickTasksWithoutTerminal(t *testing.T) {
+	store := newStore(t, task.Task{Title: "One"}, task.Task{Title: "Two", Status: task.StatusDone})
+
+	// outside a terminal, commands that would open the picker ask for task IDs instead
+	for _, args := range [][]string{
+		{"complete"},
+		{"reopen"},
+		{"remove", "--yes"},
+		{"edit", "--title", "Edited"},
+	} {
+		_, err := run(t, store, args...)
+		if err == nil || !strings.Contains(err.Error(), "no arguments provided") {
+			t.Errorf("%v: error = %v, want no arguments provided", args, err)
+		}
+	}
+	if one, two := getTask(t, store, 1), getTask(t, store, 2); one.Title != "One" || one.Complete || !two.Complete {
+		t.Errorf("tasks changed to %+v and %+v", one, two)
+	}
+	if ids := taskIDs(t, store); len(ids) != 2 {
+		t.Errorf("task IDs = %v, want 2 tasks", ids)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/tm-craggs/tidytask/task"
 	"github.com/tm-craggs/tidytask/util"
+	"strings"
 )
 
 // create struct that defines the available flags for search command
@@ -17,6 +18,9 @@ type searchFlags struct {
 	filterOpen     bool
 	filterPriority bool
 	filterNormal   bool
+	fuzzy          bool
+	regexp         bool
+	threshold      float64
 }
 
 // helper function to parse flags with error handling
@@ -45,19 +49,42 @@ func getSearchFlags(cmd *cobra.Command) (*searchFlags, error) {
 	if flags.filterNormal, err = cmd.Flags().GetBool("normal"); err != nil {
 		return nil, fmt.Errorf("failed to parse --normal flag: %w", err)
 	}
+	if flags.fuzzy, err = cmd.Flags().GetBool("fuzzy"); err != nil {
+		return nil, fmt.Errorf("failed to parse --fuzzy flag: %w", err)
+	}
+	if flags.regexp, err = cmd.Flags().GetBool("regex"); err != nil {
+		return nil, fmt.Errorf("failed to parse --regex flag: %w", err)
+	}
+	if flags.threshold, err = cmd.Flags().GetFloat64("threshold"); err != nil {
+		return nil, fmt.Errorf("failed to parse --threshold flag: %w", err)
+	}
 
 	return flags, nil
 }
 
 // searchCmd represents the search command
 var searchCmd = &cobra.Command{
-	Use:   "search [keyword] [flags]",
-	Short: "Search for tasks using a keyword",
-	Long: `The 'search' command is used to search for tasks using a keyword.
+	Use:   "search [query] [flags]",
+	Short: "Search for tasks using keywords",
+	Long: `The 'search' command is used to search for tasks using one or more keywords.
 
-By default, the keyword is matched against all fields: ID, title, and due date. 
+By default, the keywords are matched against all fields: ID, title, and due date. 
 You can narrow the scope by explicitly specifying which fields to search using the --id, --title, and --due flags
 
+Tasks must match every keyword. A query can also contain:
+- Phrases in double quotes, such as "deploy to staging"
+- Prefixes ending in *, such as depl*
+- The operators AND, OR and NOT, in upper case, and parentheses for grouping
+
+Results are ranked so the most relevant titles come first, and matches are highlighted.
+
+If you only half-remember a task, use --fuzzy. Fuzzy search tolerates typos and missing letters,
+shows how closely each task matches in a score column, and lists the best matches first.
+Query operators are not used in fuzzy mode. Use --threshold to show weaker or only stronger matches.
+
+To match a pattern, use --regex with a Go regular expression (RE2 syntax). The pattern is matched against
+each searched field, and is case-sensitive unless it starts with (?i).
+
 You can also narrow results using constraint flags, which show only tasks that meet the criteria you specify.`,
 
 	Example: `  tidytask search essay
@@ -67,7 +94,16 @@ You can also narrow results using constraint flags, which show only tasks that m
   > Search for tasks that contain 'homework' in the title, showing only priority results, 
 
   tidytask search 2024 --due --open --priority
-  > Search due dates for the number '2024', show only tasks that are both open and high-priority`,
+  > Search due dates for the number '2024', show only tasks that are both open and high-priority
+
+  tidytask search '"code review"' OR deploy* NOT docs
+  > Search for tasks containing the phrase 'code review', or a word beginning with 'deploy', but not 'docs'
+
+  tidytask search dploy stagng --fuzzy
+  > Find tasks with titles like 'Deploy to staging', despite the typos
+
+  tidytask search '^(?i)fix .*bug$' --regex --title
+  > Search for titles starting with 'fix' and ending in 'bug', ignoring case`,
 
 	RunE: func(cmd *cobra.Command, args []string) error {
 
@@ -76,8 +112,10 @@ You can also narrow results using constraint flags, which show only tasks that m
 			return fmt.Errorf("no arguments provided; keyword required")
 		}
 
-		if len(args) > 1 {
-			return fmt.Errorf("accepts 1 argument, received %d; use quotes for multi-word input", len(args))
+		// get store
+		store, err := getStore(cmd)
+		if err != nil {
+			return err
 		}
 
 		// get flags
@@ -102,20 +140,64 @@ You can also narrow results using constraint flags, which show only tasks that m
 			flags.searchDue = true
 		}
 
-		// get keyword
-		keyword := args[0]
+		if flags.fuzzy && flags.regexp {
+			return fmt.Errorf("conflicting flags: cannot use --fuzzy and --regex together")
+		}
+
+		if flags.threshold < 0 || flags.threshold > 1 {
+			return fmt.Errorf("invalid threshold %v; must be between 0 and 1", flags.threshold)
+		}
+
+		// join args to make the query
+		keyword := strings.Join(args, " ")
+
+		// build filter from constraint flags
+		filter := task.Filter{
+			Complete: flags.filterComplete,
+			Open:     flags.filterOpen,
+			Priority: flags.filterPriority,
+			Normal:   flags.filterNormal,
+		}
+
+		if flags.fuzzy {
+			return fuzzySearch(store, task.Query{
+				Keyword: keyword,
+				ID:      flags.searchID,
+				Title:   flags.searchTitle,
+				Due:     flags.searchDue,
+				Filter:  filter,
+			}, flags.threshold)
+		}
 
-		// search specified fields for the keyword
-		tasks, err := task.SearchTasks(keyword, flags.searchID, flags.searchTitle, flags.searchDue)
+		// check the query syntax, regular expressions are not highlighted
+		var highlight []string
+		if flags.regexp {
+			if _, err := task.CompileRegexp(keyword); err != nil {
+				return fmt.Errorf("invalid regular expression: %w", err)
+			}
+		} else {
+			expr, err := task.ParseSearch(keyword)
+			if err != nil {
+				return fmt.Errorf("invalid search query: %w", err)
+			}
+			highlight = expr.Terms()
+		}
+
+		// search specified fields for the keyword, filtering results using flags
+		tasks, err := store.Search(task.Query{
+			Keyword: keyword,
+			Regexp:  flags.regexp,
+			ID:      flags.searchID,
+			Title:   flags.searchTitle,
+			Due:     flags.searchDue,
+			Filter:  filter,
+		})
 		if err != nil {
 			return fmt.Errorf("failed searching tasks: %w", err)
 		}
 
-		// filter search results using flags
-		filteredTasks := util.FilterTasks(tasks, flags.filterComplete, flags.filterPriority, flags.filterOpen, flags.filterNormal)
-
 		// print tasks in table format
-		err = util.PrintTasks(filteredTasks)
+		err = util.PrintTasks(tasks, util.PrintOptions{Highlight: highlight})
 		if err != nil {
 			// handle no tasks error gracefully
 			if errors.Is(err, util.ErrNoTasks) {
@@ -130,6 +212,37 @@ You can also narrow results using constraint flags, which show only tasks that m
 	},
 }
 
+// fuzzySearch prints the tasks that closely match the query, best match first, with their scores
+func fuzzySearch(store task.Store, q task.Query, threshold float64) error {
+
+	// score tasks against the query
+	matches, err := task.FuzzySearch(store, q, threshold)
+	if err != nil {
+		return fmt.Errorf("failed searching tasks: %w", err)
+	}
+
+	// collect tasks and their scores for printing
+	tasks := make([]task.Task, len(matches))
+	scores := make(map[int]float64, len(matches))
+	for i, m := range matches {
+		tasks[i] = m.Task
+		scores[m.Task.ID] = m.Score
+	}
+
+	// print tasks in table format
+	err = util.PrintTasks(tasks, util.PrintOptions{Scores: scores})
+	if err != nil {
+		// handle no tasks error gracefully
+		if errors.Is(err, util.ErrNoTasks) {
+			fmt.Println("No results for your search.")
+			return nil
+		}
+		return fmt.Errorf("failed to print tasks: %w", err)
+	}
+
+	return nil
+}
+
 // command initialisation
 func init() {
 
@@ -146,5 +259,10 @@ func init() {
 	searchCmd.Flags().BoolP("priority", "p", false, "Show only high priority tasks")
 	searchCmd.Flags().BoolP("normal", "n", false, "Show normal priority tasks")
 
+	// matching mode flags
+	searchCmd.Flags().BoolP("fuzzy", "f", false, "Match keywords approximately, tolerating typos")
+	searchCmd.Flags().BoolP("regex", "r", false, "Match a regular expression instead of keywords")
+	searchCmd.Flags().Float64("threshold", task.DefaultFuzzyThreshold, "Lowest score, from 0 to 1, shown by a fuzzy search")
+
 	rootCmd.AddCommand(searchCmd)
 }
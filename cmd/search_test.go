@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/tm-craggs/tidytask/task"
+)
+
+func TestSearch(t *testing.T) {
+	store := newStore(t,
+		task.Task{Title: "Deploy to staging", Due: "2030-03-01"},
+		task.Task{Title: "Write deploy docs"},
+		task.Task{Title: "Buy milk", Status: task.StatusDone},
+	)
+
+	tests := []struct {
+		name     string
+		args     []string
+		want     []string
+		unwanted []string
+	}{
+		{"keyword", []string{"deploy"}, []string{"Deploy to staging", "Write deploy docs"}, []string{"milk"}},
+		{"substring", []string{"stag"}, []string{"Deploy to staging"}, []string{"docs"}},
+		{"not", []string{"deploy NOT docs"}, []string{"Deploy to staging"}, []string{"docs"}},
+		{"due field", []string{"2030-03", "--due"}, []string{"Deploy to staging"}, []string{"docs"}},
+		{"complete filter", []string{"milk", "--complete"}, []string{"milk"}, nil},
+		{"open filter", []string{"milk", "--open"}, []string{"No results for your search."}, nil},
+		{"fuzzy", []string{"dploy stagng", "--fuzzy"}, []string{"Deploy to staging"}, []string{"milk"}},
+		{"regexp", []string{"^Write", "--regex"}, []string{"Write deploy docs"}, []string{"staging"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := mustRun(t, store, append([]string{"search"}, tt.args...)...)
+			assertContains(t, out, tt.want...)
+			assertNotContains(t, out, tt.unwanted...)
+		})
+	}
+}
+
+func TestSearchErrors(t *testing.T) {
+	store := newStore(t, task.Task{Title: "Task"})
+	for _, args := range [][]string{
+		{"search"},
+		{"search", `"unclosed`},
+		{"search", "(", "--regex"},
+		{"search", "x", "--fuzzy", "--regex"},
+		{"search", "x", "--complete", "--open"},
+		{"search", "x", "--priority", "--normal"},
+	} {
+		if _, err := run(t, store, args...); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+}
+
+func TestSearchFuzzy(t *testing.T) {
+	store := newStore(t, task.Task{Title: "Deploy to staging"}, task.Task{Title: "Write deploy docs"})
+
+	out := mustRun(t, store, "search", "dploy", "stagng", "--fuzzy")
+	assertContains(t, out, "Deploy to staging", "92%")
+	assertNotContains(t, out, "Write deploy docs")
+
+	// a lower threshold shows weaker matches too
+	out = mustRun(t, store, "search", "deploy", "staging", "--fuzzy", "--threshold", "0.4")
+	assertContains(t, out, "Deploy to staging", "Write deploy docs")
+
+	out = mustRun(t, store, "search", "zzzz", "--fuzzy")
+	assertContains(t, out, "No results for your search.")
+
+	if _, err := run(t, store, "search", "deploy", "--fuzzy", "--threshold", "1.5"); err == nil {
+		t.Error("expected an error for a threshold above 1")
+	}
+}
+
+func TestSearchRegexp(t *testing.T) {
+	store := newStore(t, task.Task{Title: "fix login bug"}, task.Task{Title: "Fix search bug"})
+
+	out := mustRun(t, store, "search", "(?i)^fix .*bug$", "--regex", "--title")
+	assertContains(t, out, "fix login bug", "Fix search bug")
+
+	out = mustRun(t, store, "search", "^fix", "--regex")
+	assertContains(t, out, "fix login bug")
+	assertNotContains(t, out, "Fix search bug")
+
+	_, err := run(t, store, "search", "fix (bug", "--regex")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	assertContains(t, err.Error(), "missing closing )")
+}
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/tm-craggs/tidytask/selector"
+	"github.com/tm-craggs/tidytask/task"
+	"github.com/tm-craggs/tidytask/util"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// showField is one line of a task card
+type showField struct {
+	label string
+	value string
+}
+
+// showJSON is a task as written by show --output json, with the due date and outcome worked out as in the card.
+// fields declared here replace the embedded task fields with the same JSON name
+type showJSON struct {
+	task.Task
+	CompleteDate *string `json:"complete_date"`
+	DueRelative  string  `json:"due_relative,omitempty"`
+	Outcome      string  `json:"outcome,omitempty"`
+}
+
+// showCmd represents the show subcommand
+var showCmd = &cobra.Command{
+	Use:   "show [ID...]",
+	Short: "Show every detail of tasks",
+	Long: `The 'show' command prints every field of one or more tasks as a card, including details that list leaves
+out, such as the handle, tags, timestamps and completion date.
+
+The due date is shown both as a date and relative to today. For completed tasks, the card also shows whether
+the task was completed on time, early or late.
+
+Tasks can be chosen with IDs, handles, ranges or selectors, as for 'complete'. Use --output json for output
+that scripts can read.`,
+
+	Example: `  tidytask show 4
+  > Show every detail of task 4
+
+  tidytask show @overdue
+  > Show every overdue task
+
+  tidytask show 4 --output json
+  > Print task 4 as JSON`,
+
+	// main command logic
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		// check args
+		if len(args) == 0 {
+			return fmt.Errorf("no arguments provided; task ID required")
+		}
+
+		// get flags
+		output, err := getOutputFlag(cmd)
+		if err != nil {
+			return err
+		}
+
+		// get store
+		store, err := getStore(cmd)
+		if err != nil {
+			return err
+		}
+
+		// resolve IDs, handles, ranges and selectors
+		result, err := selector.Resolve(store, args, knownStatuses())
+		if err != nil {
+			return err
+		}
+
+		// if there are tasks in failed map, print them to terminal
+		if len(result.Failed) > 0 {
+			var keys []string
+			for sel := range result.Failed {
+				keys = append(keys, sel)
+			}
+			sort.Strings(keys)
+
+			fmt.Println("Failed to show tasks:")
+			for _, sel := range keys {
+				fmt.Printf("  - %s: %s\n", sel, result.Failed[sel])
+			}
+		}
+
+		// throw err if no tasks were found
+		if len(result.Tasks) == 0 {
+			return fmt.Errorf("no tasks to show")
+		}
+
+		if output == outputJSON {
+			return printTasksJSON(result.Tasks)
+		}
+
+		for i, t := range result.Tasks {
+			if i > 0 {
+				fmt.Println()
+			}
+			printTaskCard(t)
+		}
+
+		// exit
+		return nil
+	},
+}
+
+// printTaskCard prints every field of a task, one per line with the values lined up
+func printTaskCard(t task.Task) {
+	fields := taskFields(t)
+
+	width := 0
+	for _, f := range fields {
+		width = max(width, len(f.label))
+	}
+	for _, f := range fields {
+		fmt.Printf("%-*s  %s\n", width+1, f.label+":", f.value)
+	}
+}
+
+// taskFields lists every field of task.Task in declaration order, so new fields are shown without changes here.
+// the relative due date follows the due date, and the outcome of a completed task follows its completion date
+func taskFields(t task.Task) []showField {
+	var fields []showField
+
+	v := reflect.ValueOf(t)
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			name = field.Name
+		}
+
+		fields = append(fields, showField{label: fieldLabel(name), value: formatField(v.Field(i))})
+
+		// add values worked out from the field
+		switch name {
+		case "due":
+			if !t.Complete {
+				fields = append(fields, showField{label: "Deadline", value: util.FormatDeadline(t.Due)})
+			}
+		case "complete_date":
+			if t.Complete {
+				fields = append(fields, showField{label: "Outcome", value: util.CompletionOutcome(t)})
+			}
+		}
+	}
+
+	return fields
+}
+
+// fieldLabel turns a JSON field name such as complete_date into a label such as "Complete date"
+func fieldLabel(name string) string {
+	switch name {
+	case "id", "uuid":
+		return strings.ToUpper(name)
+	}
+	label := strings.ReplaceAll(name, "_", " ")
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+// formatField formats a task field for display, empty values are shown as "none"
+func formatField(v reflect.Value) string {
+	var s string
+
+	switch value := v.Interface().(type) {
+	case time.Time:
+		if !value.IsZero() {
+			s = value.Local().Format("2006-01-02 15:04:05")
+		}
+	case sql.NullString:
+		s = value.String
+	case []string:
+		s = strings.Join(value, ", ")
+	case bool:
+		s = "no"
+		if value {
+			s = "yes"
+		}
+	case int:
+		s = strconv.Itoa(value)
+	default:
+		s = fmt.Sprint(value)
+	}
+
+	if s == "" {
+		return "none"
+	}
+	return s
+}
+
+// printTasksJSON prints tasks as an indented JSON array
+func printTasksJSON(tasks []task.Task) error {
+	out := make([]showJSON, len(tasks))
+	for i, t := range tasks {
+		out[i] = showJSON{Task: t}
+		if t.CompleteDate.Valid {
+			out[i].CompleteDate = &t.CompleteDate.String
+		}
+		if t.Complete {
+			out[i].Outcome = util.CompletionOutcome(t)
+		} else {
+			out[i].DueRelative = util.FormatDeadline(t.Due)
+		}
+	}
+
+	return printJSON(out)
+}
+
+// command initialisation
+func init() {
+
+	// define flags and add subcommand to root
+	addOutputFlag(showCmd)
+	showCmd.ValidArgsFunction = completeTaskIDs(anyTask)
+	rootCmd.AddCommand(showCmd)
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/tm-craggs/tidytask/task"
+	"github.com/tm-craggs/tidytask/util"
+)
+
+func TestShow(t *testing.T) {
+	today := util.Today()
+	store := newStore(t,
+		task.Task{Title: "Write report", Due: today.AddDate(0, 0, 1).Format("2006-01-02"), Priority: true, Tags: []string{"work"}},
+		task.Task{Title: "Finished early", Due: today.AddDate(0, 0, 2).Format("2006-01-02"), Status: task.StatusDone},
+	)
+	one := getTask(t, store, 1)
+
+	out := mustRun(t, store, "show", "1")
+	assertContains(t, out,
+		"ID:             1",
+		"UUID:           "+one.UUID,
+		"Handle:         "+one.Handle,
+		"Title:          Write report",
+		"Deadline:       Tomorrow",
+		"Status:         todo",
+		"Complete:       no",
+		"Complete date:  none",
+		"Priority:       yes",
+		"Tags:           work",
+		"Created at:",
+	)
+	assertNotContains(t, out, "Outcome", "Finished early")
+
+	// completed tasks show their outcome instead of the deadline
+	out = mustRun(t, store, "show", "2")
+	assertContains(t, out, "Complete:       yes", "Complete date:  "+today.Format("2006-01-02"), "Outcome:        Met: 2 days early")
+	assertNotContains(t, out, "Deadline")
+
+	// tasks can be chosen by selectors, and selectors that fail are listed
+	out = mustRun(t, store, "show", "@closed", "9")
+	assertContains(t, out, "Finished early", "Failed to show tasks:", "  - 9:")
+	assertNotContains(t, out, "Write report")
+}
+
+func TestShowJSON(t *testing.T) {
+	store := newStore(t,
+		task.Task{Title: "Open", Due: "2020-01-01"},
+		task.Task{Title: "Done", Status: task.StatusDone},
+	)
+
+	out := mustRun(t, store, "show", "1", "2", "--output", "json")
+	var got []map[string]any
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d tasks, want 2", len(got))
+	}
+
+	// the completion date is a plain string or null, and the worked out fields are only given when they apply
+	open, done := got[0], got[1]
+	if open["title"] != "Open" || open["complete_date"] != nil || open["outcome"] != nil {
+		t.Errorf("open task = %v", open)
+	}
+	if due, _ := open["due_relative"].(string); len(due) < 8 || due[:8] != "Overdue:" {
+		t.Errorf("due_relative = %v, want overdue", open["due_relative"])
+	}
+	if done["complete_date"] != util.Today().Format("2006-01-02") || done["outcome"] != "Met: No due" || done["due_relative"] != nil {
+		t.Errorf("done task = %v", done)
+	}
+}
+
+func TestShowErrors(t *testing.T) {
+	store := newStore(t, task.Task{Title: "One"})
+	for _, args := range [][]string{
+		{"show"},
+		{"show", "9"},
+		{"show", "1", "--output", "xml"},
+	} {
+		if _, err := run(t, store, args...); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+}
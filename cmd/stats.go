@@ -0,0 +1,309 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/tm-craggs/tidytask/task"
+	"github.com/tm-craggs/tidytask/util"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// create struct that defines the available flags for stats command
+type statsFlags struct {
+	weeks  int
+	output string
+}
+
+// helper function to parse flags with error handling
+func getStatsFlags(cmd *cobra.Command) (statsFlags, error) {
+	var flags statsFlags
+	var err error
+
+	if flags.weeks, err = cmd.Flags().GetInt("weeks"); err != nil {
+		return flags, fmt.Errorf("failed to parse --weeks flag: %w", err)
+	}
+	if flags.weeks < 1 {
+		return flags, fmt.Errorf("invalid --weeks value %d; must be at least 1", flags.weeks)
+	}
+	if flags.output, err = getOutputFlag(cmd); err != nil {
+		return flags, err
+	}
+
+	return flags, nil
+}
+
+// taskCounts summarises a group of tasks.
+// rates are fractions between 0 and 1, and are 0 when there are no tasks to measure
+type taskCounts struct {
+	Total     int `json:"total"`
+	Open      int `json:"open"`
+	Completed int `json:"completed"`
+	Cancelled int `json:"cancelled"`
+	Overdue   int `json:"overdue"`
+
+	// completed tasks out of those not cancelled
+	CompletionRate float64 `json:"completion_rate"`
+
+	// completed tasks with a due date, by whether they were completed by the due date
+	OnTime           int     `json:"on_time"`
+	Late             int     `json:"late"`
+	OnTimeRate       float64 `json:"on_time_rate"`
+	LateRate         float64 `json:"late_rate"`
+	AverageDaysLate  float64 `json:"average_days_late"`  // among tasks completed late
+	AverageDaysEarly float64 `json:"average_days_early"` // among tasks completed on time
+}
+
+// weekCount is the number of tasks completed in the week starting on a Monday
+type weekCount struct {
+	Week      string `json:"week"`
+	Completed int    `json:"completed"`
+}
+
+// taskStats is the report shown by the stats command
+type taskStats struct {
+	taskCounts
+	Priority struct {
+		High   taskCounts `json:"high"`
+		Normal taskCounts `json:"normal"`
+	} `json:"priority"`
+	Weeks []weekCount `json:"weeks"`
+}
+
+// statsCmd represents the stats subcommand
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show statistics about your tasks",
+	Long: `The 'stats' command summarises your to-do list: how many tasks are open, completed, cancelled and overdue,
+the share of tasks completed, and how often tasks were completed by their due date.
+
+Tasks completed on or before their due date are on time, and the averages show how many days after the due
+date late tasks were completed, and how many days before it on-time tasks were. Cancelled tasks do not count
+towards the completion rate.
+
+The stats are shown for all tasks and for high and normal priority tasks, followed by the number of tasks
+completed each week, from Monday to Sunday, over the last 8 weeks. Use --weeks to change how many weeks are
+shown, and --output json for output that scripts can read.`,
+
+	Example: `  tidytask stats
+  > Show statistics for all tasks
+
+  tidytask stats --weeks 12
+  > Show tasks completed each week over the last 12 weeks
+
+  tidytask stats --output json
+  > Print the statistics as JSON`,
+
+	// main command logic
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		// check args
+		if len(args) > 0 {
+			return fmt.Errorf("unexpected arguments: %v; use --help for usage information", args)
+		}
+
+		// get flags
+		flags, err := getStatsFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		// get store
+		store, err := getStore(cmd)
+		if err != nil {
+			return err
+		}
+
+		// get every task
+		tasks, err := store.List(task.Filter{})
+		if err != nil {
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+
+		// work out the stats
+		var stats taskStats
+		var high, normal []task.Task
+		for _, t := range tasks {
+			if t.Priority {
+				high = append(high, t)
+			} else {
+				normal = append(normal, t)
+			}
+		}
+		stats.taskCounts = countTasks(tasks)
+		stats.Priority.High = countTasks(high)
+		stats.Priority.Normal = countTasks(normal)
+		stats.Weeks = completedPerWeek(tasks, flags.weeks, util.Today())
+
+		if flags.output == outputJSON {
+			return printJSON(stats)
+		}
+		return printStats(stats)
+	},
+}
+
+// countTasks works out the counts and rates for a group of tasks
+func countTasks(tasks []task.Task) taskCounts {
+	var c taskCounts
+	var daysLate, daysEarly int
+
+	// tasks due before today are overdue
+	_, overdue, _ := util.ParseDueRange("overdue")
+
+	for _, t := range tasks {
+		c.Total++
+		switch {
+		case t.Complete:
+			c.Completed++
+		case t.Status == task.StatusCancelled:
+			c.Cancelled++
+		default:
+			c.Open++
+			if t.Due != "" && t.Due <= overdue {
+				c.Overdue++
+			}
+		}
+
+		// compare the due date with the completion date
+		if !t.Complete {
+			continue
+		}
+		days, ok := util.DaysLate(t)
+		switch {
+		case !ok:
+			continue
+		case days > 0:
+			c.Late++
+			daysLate += days
+		default:
+			c.OnTime++
+			daysEarly -= days
+		}
+	}
+
+	c.CompletionRate = ratio(c.Completed, c.Total-c.Cancelled)
+	c.OnTimeRate = ratio(c.OnTime, c.OnTime+c.Late)
+	c.LateRate = ratio(c.Late, c.OnTime+c.Late)
+	c.AverageDaysLate = ratio(daysLate, c.Late)
+	c.AverageDaysEarly = ratio(daysEarly, c.OnTime)
+
+	return c
+}
+
+// ratio divides a by b, returning 0 rather than dividing by zero
+func ratio(a, b int) float64 {
+	if b == 0 {
+		return 0
+	}
+	return float64(a) / float64(b)
+}
+
+// completedPerWeek counts the tasks completed in each of the n weeks up to the one containing today, oldest first.
+// the last week is the current one, which may not have finished
+func completedPerWeek(tasks []task.Task, n int, today time.Time) []weekCount {
+	const layout = "2006-01-02"
+
+	first := util.StartOfWeek(today).AddDate(0, 0, -7*(n-1))
+	weeks := make([]weekCount, n)
+	for i := range weeks {
+		weeks[i].Week = first.AddDate(0, 0, 7*i).Format(layout)
+	}
+
+	for _, t := range tasks {
+		if !t.Complete || !t.CompleteDate.Valid {
+			continue
+		}
+		done, err := time.ParseInLocation(layout, t.CompleteDate.String, time.Local)
+		if err != nil || done.Before(first) {
+			continue
+		}
+
+		// find the week from the number of days since the first Monday
+		i := util.DaysBetween(first, done) / 7
+		if i < n {
+			weeks[i].Completed++
+		}
+	}
+
+	return weeks
+}
+
+// printStats prints the stats as tables, with a column for each priority
+func printStats(stats taskStats) error {
+	groups := []taskCounts{stats.taskCounts, stats.Priority.High, stats.Priority.Normal}
+	row := func(label string, value func(c taskCounts) string) []string {
+		r := []string{label}
+		for _, c := range groups {
+			r = append(r, value(c))
+		}
+		return r
+	}
+
+	rows := [][]string{
+		row("Total", func(c taskCounts) string { return strconv.Itoa(c.Total) }),
+		row("Open", func(c taskCounts) string { return strconv.Itoa(c.Open) }),
+		row("Completed", func(c taskCounts) string { return strconv.Itoa(c.Completed) }),
+		row("Cancelled", func(c taskCounts) string { return strconv.Itoa(c.Cancelled) }),
+		row("Overdue", func(c taskCounts) string { return strconv.Itoa(c.Overdue) }),
+		row("Completion rate", func(c taskCounts) string {
+			return formatRate(c.CompletionRate, c.Total-c.Cancelled)
+		}),
+		row("On time", func(c taskCounts) string { return formatRate(c.OnTimeRate, c.OnTime+c.Late) }),
+		row("Late", func(c taskCounts) string { return formatRate(c.LateRate, c.OnTime+c.Late) }),
+		row("Average days late", func(c taskCounts) string { return formatDays(c.AverageDaysLate, c.Late) }),
+		row("Average days early", func(c taskCounts) string {
+			return formatDays(c.AverageDaysEarly, c.OnTime)
+		}),
+	}
+	if err := util.PrintTable([]string{"", "all", "high priority", "normal priority"}, rows); err != nil {
+		return fmt.Errorf("failed to print stats: %w", err)
+	}
+
+	// find the busiest week, so the bars fit the terminal
+	most := 0
+	for _, w := range stats.Weeks {
+		most = max(most, w.Completed)
+	}
+
+	fmt.Println()
+	fmt.Println("Completed per week:")
+	rows = nil
+	for _, w := range stats.Weeks {
+		bar := ""
+		if most > 0 {
+			bar = strings.Repeat("█", w.Completed*30/most)
+		}
+		rows = append(rows, []string{w.Week, strconv.Itoa(w.Completed), bar})
+	}
+	if err := util.PrintTable([]string{"week", "completed", ""}, rows); err != nil {
+		return fmt.Errorf("failed to print stats: %w", err)
+	}
+
+	return nil
+}
+
+// formatRate formats a rate as a percentage, or "-" when there were no tasks to measure it from
+func formatRate(rate float64, of int) string {
+	if of == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", rate*100)
+}
+
+// formatDays formats an average number of days, or "-" when there were no tasks to average
+func formatDays(days float64, of int) string {
+	if of == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f", days)
+}
+
+// command initialisation
+func init() {
+
+	// define flags and add subcommand to root
+	statsCmd.Flags().IntP("weeks", "w", 8, "Number of weeks of completed tasks to show")
+	addOutputFlag(statsCmd)
+	rootCmd.AddCommand(statsCmd)
+}
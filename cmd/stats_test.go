@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tm-craggs/tidytask/task"
+	"github.com/tm-craggs/tidytask/util"
+)
+
+// completed returns a done task completed on the given date
+func completed(due, on string) task.Task {
+	return task.Task{Due: due, Status: task.StatusDone, Complete: true, CompleteDate: sql.NullString{String: on, Valid: true}}
+}
+
+func TestCountTasks(t *testing.T) {
+	overdue := util.Today().AddDate(0, 0, -1).Format("2006-01-02")
+	tasks := []task.Task{
+		{Status: task.StatusTodo, Due: overdue},
+		{Status: task.StatusDoing},
+		{Status: task.StatusCancelled, Due: overdue},
+		completed("2025-03-10", "2025-03-08"), // 2 days early
+		completed("2025-03-10", "2025-03-10"), // on the day
+		completed("2025-03-10", "2025-03-13"), // 3 days late
+		completed("", "2025-03-10"),
+	}
+
+	got := countTasks(tasks)
+	want := taskCounts{
+		Total:            7,
+		Open:             2,
+		Completed:        4,
+		Cancelled:        1,
+		Overdue:          1,
+		CompletionRate:   4.0 / 6,
+		OnTime:           2,
+		Late:             1,
+		OnTimeRate:       2.0 / 3,
+		LateRate:         1.0 / 3,
+		AverageDaysLate:  3,
+		AverageDaysEarly: 1,
+	}
+	if got != want {
+		t.Errorf("countTasks() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if got := countTasks(nil); got != (taskCounts{}) {
+		t.Errorf("countTasks(nil) = %+v, want zero counts", got)
+	}
+}
+
+func TestCompletedPerWeek(t *testing.T) {
+	// clocks go forward in New York on Sunday 2025-03-09, so that week is an hour short
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone data not available")
+	}
+	local := time.Local
+	time.Local = location
+	defer func() { time.Local = local }()
+
+	tasks := []task.Task{
+		completed("", "2025-03-03"), // Monday of the first week
+		completed("", "2025-03-09"), // Sunday, the day the clocks change
+		completed("", "2025-03-10"), // Monday of the second week
+		completed("", "2025-03-16"),
+		completed("", "2025-03-17"), // Monday of the third week
+		completed("", "2025-03-02"), // before the first week
+		{Status: task.StatusTodo},
+	}
+	today := time.Date(2025, 3, 19, 0, 0, 0, 0, location)
+
+	got := completedPerWeek(tasks, 3, today)
+	want := []weekCount{{"2025-03-03", 2}, {"2025-03-10", 2}, {"2025-03-17", 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("completedPerWeek() = %v, want %v", got, want)
+	}
+}
+
+func TestStats(t *testing.T) {
+	store := newStore(t,
+		task.Task{Title: "Open", Priority: true},
+		task.Task{Title: "Done", Status: task.StatusDone},
+		task.Task{Title: "Cancelled", Status: task.StatusCancelled},
+	)
+
+	out := mustRun(t, store, "stats", "--weeks", "2", "--output", "json")
+	var stats struct {
+		Total          int     `json:"total"`
+		Completed      int     `json:"completed"`
+		CompletionRate float64 `json:"completion_rate"`
+		Priority       struct {
+			High taskCounts `json:"high"`
+		} `json:"priority"`
+		Weeks []weekCount `json:"weeks"`
+	}
+	if err := json.Unmarshal([]byte(out), &stats); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if stats.Total != 3 || stats.Completed != 1 || stats.CompletionRate != 0.5 {
+		t.Errorf("stats = %+v", stats)
+	}
+	if stats.Priority.High.Total != 1 || stats.Priority.High.Open != 1 {
+		t.Errorf("high priority stats = %+v", stats.Priority.High)
+	}
+	if len(stats.Weeks) != 2 || stats.Weeks[1].Completed != 1 {
+		t.Errorf("weeks = %+v, want 2 weeks with 1 completed in the last", stats.Weeks)
+	}
+
+	out = mustRun(t, store, "stats")
+	assertContains(t, out, "Total", "Completed")
+
+	if _, err := run(t, store, "stats", "--weeks", "0"); err == nil {
+		t.Error("expected an error for --weeks 0")
+	}
+}
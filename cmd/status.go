@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/tm-craggs/tidytask/task"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// statusCmd represents the status subcommand
+var statusCmd = &cobra.Command{
+	Use:   "status [ID] [status]",
+	Short: "Move a task to a different status",
+	Long: `The 'status' command moves a task through your workflow.
+
+Every task has a status. The built-in statuses are:
+- todo: Not started yet. New tasks are todo.
+- doing: In progress.
+- blocked: Cannot progress until something else happens.
+- done: Complete. This is the same as using the 'complete' command.
+- cancelled: No longer needed.
+
+You can define your own statuses, such as 'review', in the "statuses" list of your config file.
+Tasks that are done or cancelled are closed, all other statuses count as open.`,
+
+	Example: `  tidytask status 4 blocked
+  > Mark task 4 as blocked
+
+  tidytask status 4 todo
+  > Move task 4 back to todo`,
+
+	// main command logic
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		// check args
+		if len(args) != 2 {
+			return fmt.Errorf("accepts 2 arguments, received %d; task ID and status required", len(args))
+		}
+
+		// check status exists
+		status := args[1]
+		if err := checkStatus(status); err != nil {
+			return err
+		}
+
+		return setStatus(cmd, args[:1], status, "update", fmt.Sprintf("Set status to %s for", status))
+	},
+}
+
+// startCmd represents the start subcommand
+var startCmd = &cobra.Command{
+	Use:   "start [ID...]",
+	Short: "Mark tasks as in progress",
+	Long: `The 'start' command marks one or more tasks as in progress, by setting their status to doing.
+
+This is the same as using 'tidytask status [ID] doing'.`,
+
+	Example: `  tidytask start 2
+  > Mark task 2 as in progress`,
+
+	// main command logic
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		// check args
+		if len(args) == 0 {
+			return fmt.Errorf("no arguments provided; task ID required")
+		}
+
+		return setStatus(cmd, args, task.StatusDoing, "start", "Started")
+	},
+}
+
+// cancelCmd represents the cancel subcommand
+var cancelCmd = &cobra.Command{
+	Use:   "cancel [ID...]",
+	Short: "Mark tasks as cancelled",
+	Long: `The 'cancel' command marks one or more tasks as cancelled, when they are no longer needed.
+
+Cancelled tasks are kept in your to-do list but count as closed. Use 'reopen' to bring them back.`,
+
+	Example: `  tidytask cancel 5 6
+  > Cancel tasks 5 and 6`,
+
+	// main command logic
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		// check args
+		if len(args) == 0 {
+			return fmt.Errorf("no arguments provided; task ID required")
+		}
+
+		return setStatus(cmd, args, task.StatusCancelled, "cancel", "Cancelled")
+	},
+}
+
+// setStatus moves each task in args to status, reporting any failures.
+// verb describes the action in error messages, and done prefixes the list of changed tasks
+func setStatus(cmd *cobra.Command, args []string, status, verb, done string) error {
+
+	// get store
+	store, err := getStore(cmd)
+	if err != nil {
+		return err
+	}
+
+	// create list of task IDs that have been changed
+	var changedIDs []int
+
+	// resolve IDs, ranges and selectors, keeping those that fail with their error message
+	ids, failed, err := selectTasks(store, args, verb, false)
+	if err != nil {
+		return err
+	}
+
+	// backup database, unless there is nothing to change
+	if len(ids) > 0 {
+		if err := store.Backup(); err != nil {
+			fmt.Printf("Warning: failed to back up database: %v", err)
+		}
+	}
+
+	// loop through selected tasks
+	for _, id := range ids {
+
+		// change status, adding to failed if needed
+		if err := store.SetStatus(id, status); err != nil {
+			failed[strconv.Itoa(id)] = err.Error()
+		} else {
+			// change successful, append ID to changed list
+			changedIDs = append(changedIDs, id)
+		}
+	}
+
+	// if there are tasks in failed map, print them to terminal
+	if len(failed) > 0 {
+
+		// exact keys and sort
+		var keys []string
+		for id := range failed {
+			keys = append(keys, id)
+		}
+		sort.Strings(keys)
+
+		// loop through sorted keys array to print failed tasks
+		fmt.Printf("Failed to %s tasks:\n", verb)
+		for _, id := range keys {
+			fmt.Printf("  - %s: %s\n", id, failed[id])
+		}
+	}
+
+	// throw err if all operations have failed
+	if len(changedIDs) == 0 {
+		return fmt.Errorf("no tasks were changed")
+	}
+
+	label := "tasks"
+	if len(changedIDs) == 1 {
+		label = "task"
+	}
+
+	sort.Ints(changedIDs)
+	fmt.Printf("%s %s: %s\n", done, label, strings.Trim(strings.Replace(fmt.Sprint(changedIDs), " ", ", ", -1), "[]"))
+
+	return nil
+}
+
+// command initialisation
+func init() {
+
+	// suggest task IDs and statuses
+	statusCmd.ValidArgsFunction = completeStatusArgs
+	startCmd.ValidArgsFunction = completeTaskIDs(openTask)
+	cancelCmd.ValidArgsFunction = completeTaskIDs(openTask)
+
+	// add subcommands to root
+	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(startCmd)
+	rootCmd.AddCommand(cancelCmd)
+}
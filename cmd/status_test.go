@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/tm-craggs/tidytask/task"
+)
+
+func TestStatus(t *testing.T) {
+	store := newStore(t, task.Task{Title: "One"}, task.Task{Title: "Two"}, task.Task{Title: "Three"})
+
+	out := mustRun(t, store, "start", "1")
+	assertContains(t, out, "Started task: 1")
+	if got := getTask(t, store, 1).Status; got != task.StatusDoing {
+		t.Errorf("task 1 status = %q, want doing", got)
+	}
+
+	out = mustRun(t, store, "status", "2", "blocked")
+	assertContains(t, out, "Set status to blocked for task: 2")
+	if got := getTask(t, store, 2).Status; got != task.StatusBlocked {
+		t.Errorf("task 2 status = %q, want blocked", got)
+	}
+
+	out = mustRun(t, store, "cancel", "3")
+	assertContains(t, out, "Cancelled task: 3")
+	if tk := getTask(t, store, 3); tk.Status != task.StatusCancelled || tk.Complete {
+		t.Errorf("task 3 = %+v, want cancelled and not complete", tk)
+	}
+
+	// done keeps the complete flag in sync
+	mustRun(t, store, "status", "1", "done")
+	if tk := getTask(t, store, 1); !tk.Complete || !tk.CompleteDate.Valid {
+		t.Errorf("task 1 = %+v, want complete", tk)
+	}
+
+	// list filters by status, and closed tasks are not open
+	out = mustRun(t, store, "list", "--status", "blocked")
+	assertContains(t, out, "Two", "blocked")
+	assertNotContains(t, out, "One", "Three")
+	out = mustRun(t, store, "list", "--open")
+	assertContains(t, out, "Two")
+	assertNotContains(t, out, "One", "Three")
+}
+
+func TestStatusErrors(t *testing.T) {
+	store := newStore(t, task.Task{Title: "One"})
+
+	for _, args := range [][]string{
+		{"status", "1"},
+		{"status", "1", "review"},
+		{"status", "9", "doing"},
+		{"start"},
+		{"cancel"},
+	} {
+		if _, err := run(t, store, args...); err == nil {
+			t.Errorf("tidytask %v: expected an error", args)
+		}
+	}
+	if got := getTask(t, store, 1).Status; got != task.StatusTodo {
+		t.Errorf("task 1 status = %q, want todo", got)
+	}
+}
+
+func TestCustomStatus(t *testing.T) {
+	writeConfig(t, `{"statuses": [{"name": "review", "color": "#AA66FF"}]}`)
+	store := newStore(t, task.Task{Title: "One"})
+
+	mustRun(t, store, "status", "1", "review")
+	if got := getTask(t, store, 1).Status; got != "review" {
+		t.Errorf("task 1 status = %q, want review", got)
+	}
+
+	// user-defined statuses are open
+	out := mustRun(t, store, "list", "--open")
+	assertContains(t, out, "One", "review")
+}
+
+func TestCustomStatusConfigErrors(t *testing.T) {
+	for _, config := range []string{
+		`{"statuses": [{"name": "done"}]}`,
+		`{"statuses": [{"name": "in review"}]}`,
+		`{"statuses": [{"name": ""}]}`,
+		`{"statuses": `,
+	} {
+		writeConfig(t, config)
+		if _, err := run(t, newStore(t), "list"); err == nil {
+			t.Errorf("config %s: expected an error", config)
+		}
+	}
+}
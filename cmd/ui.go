@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/tm-craggs/tidytask/tui"
+)
+
+// uiCmd represents the ui subcommand
+var uiCmd = &cobra.Command{
+	Use:                   "ui",
+	DisableFlagsInUseLine: true,
+	Short:                 "Manage tasks in a full-screen terminal interface",
+	Long: `The 'ui' command opens a full-screen, keyboard-driven view of your to-do list.
+
+Move through tasks with the arrow keys or j and k, and press a key to act on the selected task:
+  a  add a task              e  edit the title         t  set the due date
+  c  complete                o  reopen                 p  toggle priority
+  d  delete                  u  undo the last change   /  filter tasks as you type
+  enter  show details        ?  show every key         q  quit
+
+Changes are backed up as with any other command, so 'tidytask undo' can reverse the last change made in the
+interface.`,
+
+	Example: `  tidytask ui
+  > Open the terminal interface`,
+
+	// command logic
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		// check args
+		if len(args) > 0 {
+			return fmt.Errorf("unexpected arguments: %v; use --help for usage information", args)
+		}
+
+		// get store
+		store, err := getStore(cmd)
+		if err != nil {
+			return err
+		}
+
+		// run the interface until the user quits
+		if err := tui.Run(store); err != nil {
+			if errors.Is(err, tui.ErrNotTerminal) {
+				return fmt.Errorf("the ui command must be run in a terminal; use list to print tasks instead")
+			}
+			return fmt.Errorf("terminal interface failed: %w", err)
+		}
+
+		// exit
+		return nil
+	},
+}
+
+// command initialisation
+func init() {
+
+	// add subcommand to root
+	rootCmd.AddCommand(uiCmd)
+}
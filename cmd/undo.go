@@ -3,8 +3,6 @@ package cmd
 import (
 	"fmt"
 	"github.com/spf13/cobra"
-	"github.com/tm-craggs/tidytask/task"
-	"github.com/tm-craggs/tidytask/util"
 )
 
 var undoCmd = &cobra.Command{
@@ -27,8 +25,8 @@ After running the undo command, it becomes unavailable until a new change is mad
 			return fmt.Errorf("unexpected arguments: %v; use --help for usage information", args)
 		}
 
-		if !util.ConfirmAction("Confirm Undo?") {
-			return fmt.Errorf("aborted by user")
+		if err := confirm("Confirm Undo?"); err != nil {
+			return err
 		}
 		return nil
 	},
@@ -36,9 +34,14 @@ After running the undo command, it becomes unavailable until a new change is mad
 	// command logic
 	RunE: func(cmd *cobra.Command, args []string) error {
 
-		// attempt to restore backup, throw error if no backup file found
-		err := task.RestoreBackup()
+		// get store
+		store, err := getStore(cmd)
 		if err != nil {
+			return err
+		}
+
+		// attempt to restore backup, throw error if no backup file found
+		if err := store.Restore(); err != nil {
 			return fmt.Errorf("no backup found: %w", err)
 		}
 
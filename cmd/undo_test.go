@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/tm-craggs/tidytask/task"
+)
+
+func TestUndo(t *testing.T) {
+	store := newStore(t, task.Task{Title: "One"}, task.Task{Title: "Two"})
+
+	mustRun(t, store, "remove", "1", "--yes")
+	mustRun(t, store, "undo", "--yes")
+	if ids := taskIDs(t, store); !slices.Equal(ids, []int{1, 2}) {
+		t.Errorf("task IDs after undo = %v, want [1 2]", ids)
+	}
+
+	mustRun(t, store, "complete", "2")
+	mustRun(t, store, "undo", "--yes")
+	if getTask(t, store, 2).Complete {
+		t.Error("undo did not reopen task 2")
+	}
+
+	// the backup is used up by undo
+	if _, err := run(t, store, "undo", "--yes"); err == nil {
+		t.Error("expected an error with no backup")
+	}
+}
+
+func TestUndoNeedsConfirmation(t *testing.T) {
+	store := newStore(t, task.Task{Title: "One"})
+	mustRun(t, store, "complete", "1")
+
+	if _, err := run(t, store, "undo"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if !getTask(t, store, 1).Complete {
+		t.Error("undo ran without confirmation")
+	}
+}
+
+func TestUndoKeepsBackupWhenNothingChanges(t *testing.T) {
+	// each command is declined or selects nothing, so must leave the backup taken before it alone
+	for _, args := range [][]string{
+		{"remove", "1"},
+		{"remove", "--all"},
+		{"remove", "9", "--yes"},
+		{"complete", "9"},
+		{"complete", "1-2"},
+		{"complete", "--all", "--priority"},
+		{"reopen", "9"},
+		{"reopen", "--all"},
+		{"reopen", "--all", "--priority"},
+		{"start", "9"},
+		{"edit", "1", "--title", "Edited"},
+		{"renumber"},
+	} {
+		store := newStore(t, task.Task{Title: "One"}, task.Task{Title: "Two"})
+		if err := store.Backup(); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.Update(task.Task{ID: 1, Title: "Changed"}); err != nil {
+			t.Fatal(err)
+		}
+
+		_, _ = run(t, store, args...)
+		mustRun(t, store, "undo", "--yes")
+		if got := getTask(t, store, 1).Title; got != "One" {
+			t.Errorf("tidytask %v replaced the backup; undo restored title %q, want One", args, got)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/tm-craggs/tidytask/config"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// viewCmd represents the view command
+var viewCmd = &cobra.Command{
+	Use:   "view [NAME] [-- extra args]",
+	Short: "Run a saved list or search",
+	Long: `The 'view' command runs a saved list or search command, so you don't have to type long combinations of flags.
+
+Save a view with 'view save', giving it a name followed by -- and the command to run. Views are stored in
+your config file. Relative dates, such as --due this-week, are worked out each time the view runs.
+
+Arguments after -- when running a view are added to the saved command.`,
+
+	Example: `  tidytask view save weekly -- list --due this-week --open
+  > Save a view of open tasks due this week
+
+  tidytask view weekly
+  > Show open tasks due this week
+
+  tidytask view weekly -- --priority
+  > Show only the high priority tasks from the weekly view`,
+
+	// main command logic
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		// check args
+		if len(args) == 0 {
+			return fmt.Errorf("no arguments provided; view name required, use 'view list' to see saved views")
+		}
+
+		// find the view
+		i := findView(args[0])
+		if i < 0 {
+			return fmt.Errorf("no view named %q; use 'view list' to see saved views", args[0])
+		}
+
+		// run the saved command with any extra arguments
+		return runView(cmd, append(slices.Clone(cfg.Views[i].Args), args[1:]...))
+	},
+}
+
+// viewSaveCmd represents the view save subcommand
+var viewSaveCmd = &cobra.Command{
+	Use:   "save NAME -- COMMAND [args]",
+	Short: "Save a list or search command as a view",
+	Long: `The 'view save' command saves a list or search command under a name, replacing any view with the same name.
+
+Put -- between the name and the command, so its flags are saved instead of being read by 'view save'.`,
+
+	Example: `  tidytask view save urgent -- list --priority --due overdue
+  > Save a view of overdue high priority tasks
+
+  tidytask view save reviews -- search review --title --open
+  > Save a search for open tasks with 'review' in the title`,
+
+	Annotations: map[string]string{annotationNoDB: "true"},
+
+	// main command logic
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		// check args
+		if len(args) < 2 {
+			return fmt.Errorf("accepts a name and a command, received %d arguments; e.g. view save weekly -- list --due this-week", len(args))
+		}
+		name, viewArgs := args[0], args[1:]
+
+		// check name
+		if name == "" || strings.ContainsAny(name, " \t") || strings.HasPrefix(name, "-") {
+			return fmt.Errorf("invalid view name %q; names must be a single word", name)
+		}
+		for _, sub := range viewCmd.Commands() {
+			if sub.Name() == name || sub.HasAlias(name) {
+				return fmt.Errorf("invalid view name %q; it is a view subcommand", name)
+			}
+		}
+
+		// check the command is a list or search with valid flags
+		target, rest, err := rootCmd.Find(viewArgs)
+		if err != nil || (target != listCmd && target != searchCmd) {
+			return fmt.Errorf("invalid view command %q; views can only run list or search", viewArgs[0])
+		}
+		if err := target.ParseFlags(rest); err != nil {
+			return fmt.Errorf("invalid view command: %w", err)
+		}
+
+		// add the view, or replace an existing one
+		view := config.View{Name: name, Args: viewArgs}
+		verb := "Saved"
+		if i := findView(name); i >= 0 {
+			cfg.Views[i] = view
+			verb = "Updated"
+		} else {
+			cfg.Views = append(cfg.Views, view)
+		}
+
+		if err := config.Save(cfg); err != nil {
+			return err
+		}
+
+		fmt.Printf("%s view %s: tidytask %s\n", verb, name, formatArgs(viewArgs))
+		return nil
+	},
+}
+
+// viewListCmd represents the view list subcommand
+var viewListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show saved views",
+	Long:  `The 'view list' command shows the name of each saved view, and the command it runs.`,
+
+	Example: `  tidytask view list
+  > Show saved views`,
+
+	Annotations: map[string]string{annotationNoDB: "true"},
+
+	// main command logic
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		// check args
+		if len(args) > 0 {
+			return fmt.Errorf("unexpected arguments: %v; use --help for usage information", args)
+		}
+
+		if len(cfg.Views) == 0 {
+			fmt.Println("No saved views. Use 'view save' to create one.")
+			return nil
+		}
+
+		// pad names so the commands line up
+		width := 0
+		for _, v := range cfg.Views {
+			width = max(width, len(v.Name))
+		}
+		for _, v := range cfg.Views {
+			fmt.Printf("%-*s  tidytask %s\n", width, v.Name, formatArgs(v.Args))
+		}
+
+		return nil
+	},
+}
+
+// viewDeleteCmd represents the view delete subcommand
+var viewDeleteCmd = &cobra.Command{
+	Use:   "delete [NAME...]",
+	Short: "Delete saved views",
+	Long:  `The 'view delete' command deletes one or more saved views. Your tasks are not affected.`,
+
+	Example: `  tidytask view delete weekly
+  > Delete the view named weekly`,
+
+	Annotations: map[string]string{annotationNoDB: "true"},
+
+	// main command logic
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		// check args
+		if len(args) == 0 {
+			return fmt.Errorf("no arguments provided; view name required")
+		}
+
+		// check every view exists before deleting any
+		for _, name := range args {
+			if findView(name) < 0 {
+				return fmt.Errorf("no view named %q; use 'view list' to see saved views", name)
+			}
+		}
+
+		cfg.Views = slices.DeleteFunc(cfg.Views, func(v config.View) bool {
+			return slices.Contains(args, v.Name)
+		})
+
+		if err := config.Save(cfg); err != nil {
+			return err
+		}
+
+		fmt.Printf("Deleted views: %s\n", strings.Join(args, ", "))
+		return nil
+	},
+}
+
+// findView returns the index of the view called name in the config, or -1 if there is none
+func findView(name string) int {
+	return slices.IndexFunc(cfg.Views, func(v config.View) bool {
+		return v.Name == name
+	})
+}
+
+// runView runs a saved list or search command, using the store already opened for cmd
+func runView(cmd *cobra.Command, args []string) error {
+
+	// find the saved command, its flags are among the remaining args
+	target, rest, err := rootCmd.Find(args)
+	if err != nil || (target != listCmd && target != searchCmd) {
+		return fmt.Errorf("invalid view command %q; views can only run list or search", args[0])
+	}
+
+	if err := target.ParseFlags(rest); err != nil {
+		return fmt.Errorf("invalid view command: %w", err)
+	}
+
+	// hand the store to the saved command
+	target.SetContext(cmd.Context())
+	return target.RunE(target, target.Flags().Args())
+}
+
+// formatArgs joins args for display, quoting any that contain spaces
+func formatArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\"'") {
+			arg = strconv.Quote(arg)
+		}
+		quoted[i] = arg
+	}
+	return strings.Join(quoted, " ")
+}
+
+// command initialisation
+func init() {
+
+	// suggest view names
+	viewCmd.ValidArgsFunction = completeView
+	viewDeleteCmd.ValidArgsFunction = completeViews
+
+	// add subcommands to view, and view to root
+	viewCmd.AddCommand(viewSaveCmd)
+	viewCmd.AddCommand(viewListCmd)
+	viewCmd.AddCommand(viewDeleteCmd)
+	rootCmd.AddCommand(viewCmd)
+}
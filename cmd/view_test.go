@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/tm-craggs/tidytask/config"
+	"github.com/tm-craggs/tidytask/task"
+)
+
+func TestView(t *testing.T) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	store := newStore(t,
+		task.Task{Title: "Due today", Due: today.Format("2006-01-02")},
+		task.Task{Title: "Urgent today", Due: today.Format("2006-01-02"), Priority: true},
+		task.Task{Title: "Due later", Due: today.AddDate(0, 2, 0).Format("2006-01-02")},
+		task.Task{Title: "Review docs"},
+	)
+
+	out := mustRun(t, store, "view", "save", "today", "--", "list", "--due", "today")
+	assertContains(t, out, "Saved view today: tidytask list --due today")
+	out = mustRun(t, store, "view", "save", "reviews", "--", "search", "review", "--title")
+	assertContains(t, out, "Saved view reviews")
+
+	// the views are saved in the config file
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Views) != 2 || cfg.Views[0].Name != "today" || cfg.Views[1].Args[1] != "review" {
+		t.Errorf("views in config = %+v", cfg.Views)
+	}
+
+	out = mustRun(t, store, "view", "today")
+	assertContains(t, out, "Due today", "Urgent today")
+	assertNotContains(t, out, "Due later", "Review docs")
+
+	// extra arguments are added to the saved command
+	out = mustRun(t, store, "view", "today", "--", "--priority")
+	assertContains(t, out, "Urgent today")
+	assertNotContains(t, out, "Due today")
+
+	out = mustRun(t, store, "view", "reviews")
+	assertContains(t, out, "Review docs")
+	assertNotContains(t, out, "Due today")
+
+	out = mustRun(t, store, "view", "list")
+	assertContains(t, out, "today    tidytask list --due today", "reviews  tidytask search review --title")
+
+	// saving a view with the same name replaces it
+	out = mustRun(t, store, "view", "save", "today", "--", "list", "--due", "today", "--normal")
+	assertContains(t, out, "Updated view today")
+	out = mustRun(t, store, "view", "today")
+	assertNotContains(t, out, "Urgent today")
+
+	out = mustRun(t, store, "view", "delete", "today", "reviews")
+	assertContains(t, out, "Deleted views: today, reviews")
+	out = mustRun(t, store, "view", "list")
+	assertContains(t, out, "No saved views.")
+
+	// views never change the tasks
+	if ids := taskIDs(t, store); len(ids) != 4 {
+		t.Errorf("task IDs = %v, want 4 tasks", ids)
+	}
+}
+
+func TestViewErrors(t *testing.T) {
+	store := newStore(t)
+	mustRun(t, store, "view", "save", "weekly", "--", "list", "--due", "this-week")
+
+	for _, args := range [][]string{
+		{"view"},
+		{"view", "missing"},
+		{"view", "save", "weekly"},
+		{"view", "save", "list", "--", "list"},
+		{"view", "save", "-x", "--", "list"},
+		{"view", "save", "bad", "--", "add", "task"},
+		{"view", "save", "bad", "--", "list", "--no-such-flag"},
+		{"view", "delete"},
+		{"view", "delete", "weekly", "missing"},
+	} {
+		if _, err := run(t, store, args...); err == nil {
+			t.Errorf("tidytask %v: expected an error", args)
+		}
+	}
+
+	// a failed delete removes nothing
+	out := mustRun(t, store, "view", "list")
+	assertContains(t, out, "weekly")
+}
+
+func TestViewWithoutConfig(t *testing.T) {
+	// a missing config file means there are no views, and saving one creates it
+	store := newStore(t)
+	out := mustRun(t, store, "view", "list")
+	assertContains(t, out, "No saved views.")
+
+	mustRun(t, store, "view", "save", "all", "--", "list")
+	if _, err := os.Stat(os.Getenv(config.EnvConfig)); err != nil {
+		t.Errorf("config file not written: %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// EnvConfig is the environment variable that overrides the config file path
+const EnvConfig = "TIDYTASK_CONFIG"
+
+// Config holds the user's settings
+type Config struct {
+	Statuses []Status `json:"statuses,omitempty"` // user-defined statuses, in addition to the built-in ones
+	Views    []View   `json:"views,omitempty"`    // saved list and search commands
+	Urgency  Urgency  `json:"urgency,omitzero"`   // weights of the urgency score used by 'next'
+}
+
+// Status is a user-defined task status, such as "review" or "waiting".
+// user-defined statuses are open, tasks with them still count as work to do
+type Status struct {
+	Name  string `json:"name"`  // name used on the command line, e.g. "review"
+	Color string `json:"color"` // hex colour used when listing tasks, e.g. "#AA66FF" (optional)
+}
+
+// View is a saved list or search command, run with 'tidytask view NAME'.
+// the arguments are stored as typed, so relative dates such as "--due this-week" are resolved each time it runs
+type View struct {
+	Name string   `json:"name"` // name of the view, e.g. "weekly"
+	Args []string `json:"args"` // command and its arguments, e.g. ["list", "--due", "this-week"]
+}
+
+// Urgency sets the coefficients of the urgency score used by 'next'.
+// each is optional, and the default is used for any that are not set
+type Urgency struct {
+	Priority *float64 `json:"priority,omitempty"` // added for high priority tasks
+	Due      *float64 `json:"due,omitempty"`      // full when due today or overdue, less the further away it is
+	Overdue  *float64 `json:"overdue,omitempty"`  // grows with days overdue, full after a week
+	Age      *float64 `json:"age,omitempty"`      // grows with age, full after a year
+	Blocked  *float64 `json:"blocked,omitempty"`  // added for blocked tasks, negative to rank them lower
+}
+
+// Path returns the path of the config file.
+// it is config.json in the TidyTask config directory, unless overridden by TIDYTASK_CONFIG
+func Path() (string, error) {
+	if env := os.Getenv(EnvConfig); env != "" {
+		return env, nil
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get filepath for config directory: %w", err)
+	}
+	return filepath.Join(configDir, "tidytask", "config.json"), nil
+}
+
+// Load reads the config file, returning the default config if it does not exist
+func Load() (Config, error) {
+	var cfg Config
+
+	path, err := Path()
+	if err != nil {
+		return cfg, err
+	}
+
+	// a missing config file is not an error
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// Save writes cfg to the config file, creating the config directory if needed
+func Save(cfg Config) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return nil
+}
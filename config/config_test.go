@@ -0,0 +1,80 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPath(t *testing.T) {
+	t.Setenv(EnvConfig, "/tmp/tidytask-test.json")
+	if got, err := Path(); err != nil || got != "/tmp/tidytask-test.json" {
+		t.Errorf("Path() = %q, %v, want the %s path", got, err, EnvConfig)
+	}
+
+	t.Setenv(EnvConfig, "")
+	got, err := Path()
+	if err != nil {
+		t.Skip("no user config directory")
+	}
+	if filepath.Base(got) != "config.json" || filepath.Base(filepath.Dir(got)) != "tidytask" {
+		t.Errorf("Path() = %q, want tidytask/config.json in the user config directory", got)
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	t.Setenv(EnvConfig, filepath.Join(t.TempDir(), "config.json"))
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cfg, Config{}) {
+		t.Errorf("Load() = %+v, want the default config", cfg)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "config.json")
+	t.Setenv(EnvConfig, path)
+
+	priority := 10.0
+	want := Config{
+		Statuses: []Status{{Name: "review", Color: "#AA66FF"}},
+		Views:    []View{{Name: "weekly", Args: []string{"list", "--due", "this-week"}}},
+		Urgency:  Urgency{Priority: &priority},
+	}
+	if err := Save(want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Load() = %+v, want %+v", got, want)
+	}
+
+	// settings that are not set are left out of the file
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, unset := range []string{`"due"`, `"blocked"`} {
+		if strings.Contains(string(data), unset) {
+			t.Errorf("config file contains %s:\n%s", unset, data)
+		}
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	t.Setenv(EnvConfig, path)
+	if err := os.WriteFile(path, []byte(`{"views": [`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(); err == nil {
+		t.Error("Load() of invalid JSON did not return an error")
+	}
+}
@@ -0,0 +1,5 @@
+// Package config loads the optional TidyTask configuration file.
+//
+// The file is JSON, stored as config.json in the TidyTask config directory, and every setting has a default,
+// so TidyTask works without one. Some commands, such as 'view save', also write to it.
+package config
@@ -0,0 +1,13 @@
+// Package selector resolves the task selectors given to commands such as complete, remove and reopen.
+//
+// Each argument is one or more selectors separated by commas. A selector can be:
+//   - a task ID, e.g. 4
+//   - a task handle or UUID, e.g. k3fa, which stay the same when IDs change
+//   - a range of IDs, e.g. 3-8, selecting the tasks in the range that exist
+//   - last, the most recently added task
+//   - @overdue, open tasks due before today
+//   - @today, @tomorrow, @this-week, @next-week or @this-month, tasks due then
+//   - @open, @closed, @priority or @normal
+//   - @tag: followed by a tag, e.g. @tag:sprint-5
+//   - @ followed by a status, e.g. @doing
+package selector
@@ -0,0 +1,190 @@
+package selector
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/tm-craggs/tidytask/task"
+	"github.com/tm-craggs/tidytask/util"
+)
+
+// Result holds the tasks chosen by a set of selectors
+type Result struct {
+	Tasks  []task.Task       // selected tasks, in the order they were selected, without duplicates
+	Failed map[string]string // selectors that could not be resolved, and why
+}
+
+// IDs returns the IDs of the selected tasks
+func (r Result) IDs() []int {
+	ids := make([]int, len(r.Tasks))
+	for i, t := range r.Tasks {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
+// IsPlain reports whether args are all plain task IDs, handles or UUIDs, so the user already knows exactly which
+// tasks they chose
+func IsPlain(args []string) bool {
+	for _, arg := range args {
+		lower := strings.ToLower(arg)
+		if _, err := strconv.Atoi(arg); err != nil && !task.IsHandle(lower) && !task.IsUUID(lower) {
+			return false
+		}
+	}
+	return true
+}
+
+// Resolve returns the tasks chosen by the selectors in args.
+// statuses lists the statuses that can be selected with @, and an error is only returned if the tasks cannot be read.
+func Resolve(store task.Store, args []string, statuses []string) (Result, error) {
+	result := Result{Failed: make(map[string]string)}
+
+	// get every task once, selectors pick from these in display order
+	tasks, err := store.List(task.Filter{})
+	if err != nil {
+		return result, fmt.Errorf("failed to retrieve tasks: %w", err)
+	}
+
+	// add each selected task, skipping tasks already selected
+	selected := make(map[int]bool)
+	add := func(t task.Task) {
+		if !selected[t.ID] {
+			selected[t.ID] = true
+			result.Tasks = append(result.Tasks, t)
+		}
+	}
+
+	// resolve each selector, an argument may hold several separated by commas
+	for _, arg := range args {
+		for _, sel := range strings.Split(arg, ",") {
+			if sel == "" {
+				continue
+			}
+
+			matched, err := resolveOne(tasks, sel, statuses)
+			if err != nil {
+				result.Failed[sel] = err.Error()
+				continue
+			}
+			for _, t := range matched {
+				add(t)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// resolveOne returns the tasks chosen by a single selector, or an error if it is invalid or chooses nothing
+func resolveOne(tasks []task.Task, sel string, statuses []string) ([]task.Task, error) {
+
+	// a plain ID must exist
+	if id, err := strconv.Atoi(sel); err == nil {
+		i := slices.IndexFunc(tasks, func(t task.Task) bool { return t.ID == id })
+		if i < 0 {
+			return nil, &task.NotFoundError{ID: id}
+		}
+		return tasks[i : i+1], nil
+	}
+
+	// a handle or UUID must belong to a task, they are matched ignoring case
+	if lower := strings.ToLower(sel); task.IsHandle(lower) || task.IsUUID(lower) {
+		i := slices.IndexFunc(tasks, func(t task.Task) bool { return t.Handle == lower || t.UUID == lower })
+		if i < 0 {
+			return nil, fmt.Errorf("no task with handle or UUID %s", sel)
+		}
+		return tasks[i : i+1], nil
+	}
+
+	var match func(t task.Task) bool
+
+	switch {
+	case sel == "last":
+		// the most recently added task, latest ID first when added in the same second
+		if len(tasks) == 0 {
+			return nil, fmt.Errorf("no tasks")
+		}
+		last := tasks[0]
+		for _, t := range tasks[1:] {
+			if t.CreatedAt.After(last.CreatedAt) || (t.CreatedAt.Equal(last.CreatedAt) && t.ID > last.ID) {
+				last = t
+			}
+		}
+		return []task.Task{last}, nil
+
+	case strings.HasPrefix(sel, "@"):
+		var err error
+		if match, err = matcher(sel[1:], statuses); err != nil {
+			return nil, err
+		}
+
+	case strings.Contains(sel, "-"):
+		// a range of IDs, in either order
+		from, to, ok := strings.Cut(sel, "-")
+		start, err1 := strconv.Atoi(from)
+		end, err2 := strconv.Atoi(to)
+		if !ok || err1 != nil || err2 != nil {
+			return nil, fmt.Errorf("invalid range; use two task IDs, e.g. 3-8")
+		}
+		if start > end {
+			start, end = end, start
+		}
+		match = func(t task.Task) bool { return t.ID >= start && t.ID <= end }
+
+	default:
+		return nil, fmt.Errorf("invalid task ID, handle or selector")
+	}
+
+	// select every matching task
+	var matched []task.Task
+	for _, t := range tasks {
+		if match(t) {
+			matched = append(matched, t)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("no matching tasks")
+	}
+
+	return matched, nil
+}
+
+// matcher returns a function that reports whether a task is chosen by the @ selector with the given name
+func matcher(name string, statuses []string) (func(t task.Task) bool, error) {
+	switch name {
+	case "open":
+		return func(t task.Task) bool { return !t.Closed() }, nil
+	case "closed":
+		return func(t task.Task) bool { return t.Closed() }, nil
+	case "priority":
+		return func(t task.Task) bool { return t.Priority }, nil
+	case "normal":
+		return func(t task.Task) bool { return !t.Priority }, nil
+	}
+
+	// tag:NAME selects tasks with the tag
+	if tag, ok := strings.CutPrefix(name, "tag:"); ok && tag != "" {
+		return func(t task.Task) bool { return t.HasTag(tag) }, nil
+	}
+
+	// statuses select tasks with that status
+	if slices.Contains(statuses, name) {
+		return func(t task.Task) bool { return t.Status == name }, nil
+	}
+
+	// relative due dates select tasks due within the range, overdue tasks must also be open
+	if slices.Contains(util.DueKeywords, name) {
+		from, to, err := util.ParseDueRange(name)
+		if err != nil {
+			return nil, err
+		}
+		filter := task.Filter{DueFrom: from, DueTo: to, Open: name == "overdue"}
+		return filter.Match, nil
+	}
+
+	return nil, fmt.Errorf("unknown selector; use @open, @closed, @priority, @normal, @tag:NAME, a status, or one of @%s",
+		strings.Join(util.DueKeywords, ", @"))
+}
@@ -0,0 +1,148 @@
+package selector
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tm-craggs/tidytask/task"
+)
+
+// newStore returns a store holding five tasks:
+//  1. a, overdue
+//  2. b, due today, high priority, tagged sprint
+//  3. c, done, due before today
+//  4. d, doing, no due date
+//  5. e, due in 30 days
+func newStore(t *testing.T) task.Store {
+	t.Helper()
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	day := func(offset int) string { return today.AddDate(0, 0, offset).Format("2006-01-02") }
+
+	store := task.NewMemoryStore()
+	for _, tk := range []task.Task{
+		{Title: "a", Due: day(-1)},
+		{Title: "b", Due: day(0), Priority: true, Tags: []string{"sprint"}},
+		{Title: "c", Due: day(-2)},
+		{Title: "d"},
+		{Title: "e", Due: day(30)},
+	} {
+		if _, err := store.Add(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Complete(3); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetStatus(4, task.StatusDoing); err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestResolve(t *testing.T) {
+	store := newStore(t)
+	statuses := task.BuiltinStatuses
+
+	// tasks chosen by one selector are in display order, and selectors are resolved in the order given
+	tests := []struct {
+		name string
+		args []string
+		want []int
+	}{
+		{"id", []string{"2"}, []int{2}},
+		{"several ids", []string{"4", "1"}, []int{4, 1}},
+		{"comma list", []string{"3,1,3"}, []int{3, 1}},
+		{"range", []string{"2-4"}, []int{2, 4, 3}},
+		{"reversed range", []string{"4-2"}, []int{2, 4, 3}},
+		{"range past the end", []string{"4-9"}, []int{5, 4}},
+		{"range and id", []string{"1-2,5"}, []int{2, 1, 5}},
+		{"last", []string{"last"}, []int{5}},
+		{"overdue", []string{"@overdue"}, []int{1}},
+		{"today", []string{"@today"}, []int{2}},
+		{"open", []string{"@open"}, []int{2, 1, 5, 4}},
+		{"closed", []string{"@closed"}, []int{3}},
+		{"priority", []string{"@priority"}, []int{2}},
+		{"tag", []string{"@tag:sprint"}, []int{2}},
+		{"status", []string{"@doing"}, []int{4}},
+		{"duplicates", []string{"@today", "2", "1-2"}, []int{2, 1}},
+	}
+	for _, tt := range tests {
+		result, err := Resolve(store, tt.args, statuses)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if len(result.Failed) > 0 {
+			t.Errorf("%s: failed %v", tt.name, result.Failed)
+		}
+		if got := result.IDs(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Resolve(%v) = %v, want %v", tt.name, tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestResolveHandleAndUUID(t *testing.T) {
+	store := newStore(t)
+	tk, err := store.Get(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, arg := range []string{tk.Handle, strings.ToUpper(tk.Handle), tk.UUID, strings.ToUpper(tk.UUID)} {
+		result, err := Resolve(store, []string{arg}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := result.IDs(); !reflect.DeepEqual(got, []int{4}) || len(result.Failed) > 0 {
+			t.Errorf("Resolve(%s) = %v, failed %v; want [4]", arg, got, result.Failed)
+		}
+	}
+}
+
+func TestResolveFailures(t *testing.T) {
+	store := newStore(t)
+
+	// each selector fails on its own, and valid selectors alongside it are still chosen
+	for _, sel := range []string{"9", "20-30", "1-x", "@nothing", "@tag:none", "@blocked", "zzzz", "first"} {
+		result, err := Resolve(store, []string{sel, "1"}, task.BuiltinStatuses)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := result.Failed[sel]; !ok {
+			t.Errorf("Resolve(%s) did not fail, got %v", sel, result.IDs())
+		}
+		if got := result.IDs(); !reflect.DeepEqual(got, []int{1}) {
+			t.Errorf("Resolve(%s, 1) = %v, want [1]", sel, got)
+		}
+	}
+
+	// last fails when there are no tasks
+	result, err := Resolve(task.NewMemoryStore(), []string{"last"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Tasks) != 0 || result.Failed["last"] == "" {
+		t.Errorf("last with no tasks = %v, failed %v", result.IDs(), result.Failed)
+	}
+}
+
+func TestIsPlain(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"1", "22"}, true},
+		{[]string{"1", "k3fa"}, true},
+		{[]string{"1-3"}, false},
+		{[]string{"1,2"}, false},
+		{[]string{"last"}, false},
+		{[]string{"@today"}, false},
+	}
+	for _, tt := range tests {
+		if got := IsPlain(tt.args); got != tt.want {
+			t.Errorf("IsPlain(%v) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package task
+
+import "context"
+
+// storeKey is the context key under which a Store is stored
+type storeKey struct{}
+
+// NewContext returns a copy of ctx carrying the given store
+func NewContext(ctx context.Context, s Store) context.Context {
+	return context.WithValue(ctx, storeKey{}, s)
+}
+
+// FromContext returns the store carried by ctx, and whether one was present
+func FromContext(ctx context.Context) (Store, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	s, ok := ctx.Value(storeKey{}).(Store)
+	return s, ok
+}
@@ -2,79 +2,169 @@ package task
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
-	_ "github.com/mattn/go-sqlite3"
 	"log"
 	"os"
 	"path/filepath"
 	"strings"
-	"time"
 )
 
-// DB is a global variable representing the database connection
-var DB *sql.DB
+// MemoryPath is the special database path that opens an ephemeral in-memory database.
+// Nothing is written to disk, so backups are skipped and undo is unavailable.
+const MemoryPath = ":memory:"
 
-func getDBPath() string {
+// ErrNoBackupInMemory is returned when restoring a backup of an in-memory database
+var ErrNoBackupInMemory = errors.New("in-memory databases have no backup")
+
+// taskColumns lists the columns selected for every task query, in the order scanned by scanTasks
+const taskColumns = "id, uuid, handle, title, due, complete, priority, complete_date, created_at, updated_at, status, " +
+	"IFNULL((SELECT GROUP_CONCAT(tag, ' ') FROM task_tags WHERE task_id = tasks.id), '')"
+
+// closedStatuses is an SQL list of the statuses for which IsClosedStatus is true
+const closedStatuses = "('" + StatusDone + "', '" + StatusCancelled + "')"
+
+// displayOrder orders tasks by completion status, priority, presence of a due date, and due date ascending
+const displayOrder = `
+		ORDER BY` + displayOrderTerms
+
+// displayOrderTerms are the terms of displayOrder, for queries that need to order by something else first
+const displayOrderTerms = `
+		    status IN ` + closedStatuses + ` ASC, -- open tasks first, ASC puts false (0) before true (1)
+			priority DESC, -- among incomplete tasks, priority DESC puts priority tasks first
+			IFNULL(due, '') != '' DESC,  -- tasks with a due date come before tasks without a due date
+			due ASC,  -- tasks are sorted by ascending due date, earliest first
+			id ASC`
+
+// SQLiteStore is a Store backed by an SQLite database file
+type SQLiteStore struct {
+	db   *sql.DB
+	path string
+	fts  bool // the tasks_fts full-text index is available
+}
+
+// updateStmt replaces the editable fields of a task, taking the title, due date, priority, update time and ID
+const updateStmt = "UPDATE tasks SET title = ?, due = ?, priority = ?, updated_at = ? WHERE id = ?"
+
+// setStatusStmt changes the status of a task, keeping complete and complete_date in sync.
+// its arguments are built by setStatusArgs. complete_date is only set if it is NULL, so an existing completion
+// date is kept, and updated_at is only set if the status, complete or complete_date change
+const setStatusStmt = `
+	UPDATE tasks
+	SET status = ?,
+	    complete = (? = '` + StatusDone + `'),
+	    complete_date = CASE
+	        WHEN ? != '` + StatusDone + `' THEN NULL
+	        WHEN complete_date IS NULL THEN ?
+	        ELSE complete_date
+	    END,
+	    updated_at = CASE
+	        WHEN status IS NOT ? OR complete IS NOT (? = '` + StatusDone + `')
+	            OR (complete_date IS NULL) = (? = '` + StatusDone + `') THEN ?
+	        ELSE updated_at
+	    END
+	WHERE id = ?`
+
+// setStatusArgs returns the arguments of setStatusStmt, which moves the task with the given ID to status,
+// completing it on date and stamping it with the update time stamp
+func setStatusArgs(id int, status, date, stamp string) []interface{} {
+	return []interface{}{status, status, status, date, status, status, status, stamp, id}
+}
+
+// getDBPath returns the path of the per-user database in the config directory, creating the directory if needed
+func getDBPath() (string, error) {
 	configDir, err := os.UserConfigDir()
 	if err != nil {
-		log.Fatal("Failed to get filepath for config directory", err)
+		return "", fmt.Errorf("failed to get filepath for config directory: %w", err)
 	}
 	appDir := filepath.Join(configDir, "tidytask")
 
 	// create app directory if it doesn't exist
 	if err := os.MkdirAll(appDir, 0755); err != nil {
-		log.Fatal("Failed to create config directory: ", err)
+		return "", fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	return filepath.Join(appDir, "tasks.db")
+	return filepath.Join(appDir, "tasks.db"), nil
 }
 
-// InitDB creates the SQLite database by creating the database file and the tasks table if it does not exist
-func InitDB() error {
+// Open opens the SQLite database at dbPath, creating the database file if it does not exist,
+// and brings its schema up to date
+func Open(dbPath string) (*SQLiteStore, error) {
 
-	// open, or create if not exists, the SQLite database file "tasks.db"
-	var err error
+	// open, or create if not exists, the SQLite database file
+	db, err := sql.Open(driverName, dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
 
-	// create database at dbPath
-	dbPath := getDBPath()
-	DB, err = sql.Open("sqlite3", dbPath)
+	// each connection to :memory: is a separate database, so only ever use one
+	if dbPath == MemoryPath {
+		db.SetMaxOpenConns(1)
+	}
+
+	// create or upgrade the tasks table, closing the database on error
+	if err := migrate(db); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	// create the full-text index, if supported by this build
+	fts, err := setupFTS(db)
 	if err != nil {
-		log.Fatal(err)
-	}
-
-	// define database table according to the Task struct
-	createTable := `
-	CREATE TABLE IF NOT EXISTS tasks (
-		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		title TEXT NOT NULL,
-		due TEXT,
-		complete BOOLEAN NOT NULL DEFAULT false,
-		priority BOOLEAN NOT NULL DEFAULT false,
-		complete_date TEXT          
-	);`
-
-	// execute the SQL statement, if error: log and exit
-	_, err = DB.Exec(createTable)
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to set up full-text search: %w", err)
+	}
+
+	return &SQLiteStore{db: db, path: dbPath, fts: fts}, nil
+}
+
+// Path returns the path of the database file
+func (s *SQLiteStore) Path() string {
+	return s.path
+}
+
+// NextID returns the ID SQLite will give the next added task, one more than the highest ID ever used
+func (s *SQLiteStore) NextID() (int, error) {
+	var seq int
+	err := s.db.QueryRow("SELECT IFNULL((SELECT seq FROM sqlite_sequence WHERE name = 'tasks'), 0)").Scan(&seq)
 	if err != nil {
-		return fmt.Errorf("failed to create table: %w", err)
+		return 0, err
 	}
+	return seq + 1, nil
+}
 
-	return nil
+// Close safely closes the database connection, if it is open.
+// it returns any error encountered during close, and closing an already closed store returns nil
+func (s *SQLiteStore) Close() error {
+	// check if DB connection exists before trying to close it
+	if s.db == nil {
+		// nothing to close, return nil
+		return nil
+	}
+
+	// close the connection and return any errors
+	err := s.db.Close()
+	s.db = nil
+	return err
 }
 
-// HardReset deletes both the main database file and the backup file, if they exist.
-func HardReset() error {
+// Reset deletes both the database file and its backup file, if they exist.
+func (s *SQLiteStore) Reset() error {
 	// close the DB connection if open
-	if err := CloseDB(); err != nil {
+	if err := s.Close(); err != nil {
 		return fmt.Errorf("failed to close DB before reset: %w", err)
 	}
 
-	// get main DB and backup paths
-	dbPath := getDBPath()
-	backupPath := dbPath + ".bak"
+	// closing an in-memory database discards it, there are no files to delete
+	if s.path == MemoryPath {
+		return nil
+	}
+
+	// get backup path
+	backupPath := s.path + ".bak"
 
 	// delete the main database file
-	if err := os.Remove(dbPath); err != nil && !os.IsNotExist(err) {
+	if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to delete database file: %w", err)
 	}
 
@@ -86,226 +176,502 @@ func HardReset() error {
 	return nil
 }
 
-// CloseDB safely closes the database connection, if it had been opened.
-// it returns any error encountered during close, and if the DB was never initialised, it returns nil
-// this function is always called on exit of tidytask
-func CloseDB() error {
-	// check if DB connection exists before trying to close it
-	if DB != nil {
-		// close the connection and return any errors
-		return DB.Close()
-	}
-	// nothing to close, return nil
-	return nil
-}
-
-// CheckTaskExists checks if a task with the given ID exists in the database.
-// It returns an error if the task does not exist or if there is a query error.
-func CheckTaskExists(id int) error {
+// Get retrieves the task with the given ID.
+// It returns a NotFoundError if the task does not exist, or an error if there is a query error.
+func (s *SQLiteStore) Get(id int) (Task, error) {
 
-	// exists will store whether the task exists (true) or not (false)
-	var exists bool
-
-	// SQL query returns true if at least one row with the given ID exists in the tasks table
-	query := "SELECT EXISTS(SELECT 1 FROM tasks WHERE id = ?)"
+	// execute query for the single matching row
+	rows, err := s.db.Query("SELECT "+taskColumns+" FROM tasks WHERE id = ?", id)
+	if err != nil {
+		return Task{}, fmt.Errorf("query error getting task: %w", err)
+	}
 
-	// execute query and scan the result into the exists variable
-	err := DB.QueryRow(query, id).Scan(&exists)
+	tasks, err := scanTasks(rows)
 	if err != nil {
-		return fmt.Errorf("query error checking task existence: %w", err)
+		return Task{}, err
 	}
 
-	// if exists is false, no task with ID was found, return an error
-	if !exists {
-		return fmt.Errorf("task with ID %d does not exist", id)
+	// if no row was returned, no task with ID was found
+	if len(tasks) == 0 {
+		return Task{}, &NotFoundError{ID: id}
 	}
 
-	// if the task exists, return no error
-	return nil
+	return tasks[0], nil
 }
 
-// AddTask inserts a new task into the tasks database table by taking in a task struct
-func AddTask(t Task) error {
+// Add inserts a new task into the tasks database table and returns its ID
+func (s *SQLiteStore) Add(t Task) (int, error) {
+
+	// new tasks are todo unless given another open status
+	status := t.Status
+	if status == "" || IsClosedStatus(status) {
+		status = StatusTodo
+	}
 
 	// SQL insert statement to add a new task, setting complete_date to NULL
-	stmt := `INSERT INTO tasks (title, due, complete, priority, complete_date) VALUES (?, ?, ?, ?, NULL)`
+	stmt := `INSERT INTO tasks (uuid, handle, title, due, complete, priority, complete_date, created_at, updated_at, status)
+		VALUES (?, ?, ?, ?, 0, ?, NULL, ?, ?, ?)`
 
-	// execute the insert statement with the task's fields as parameters
-	_, err := DB.Exec(stmt, t.Title, t.Due, t.Complete, t.Priority)
+	// insert the task and its tags together
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
 
-	// return any error encountered
-	return err
-}
+	// give the task a UUID and a handle no other task has
+	uuid, handle, err := newIdentity(handleTaken(tx))
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	// execute the insert statement with the task's fields as parameters
+	stamp := formatTimestamp(now())
+	res, err := tx.Exec(stmt, uuid, handle, t.Title, t.Due, t.Priority, stamp, stamp, status)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
 
-// RemoveTask deletes the task with the specified ID from the database.
-func RemoveTask(id int) error {
+	// get the assigned ID
+	id, err := res.LastInsertId()
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
 
-	// execute DELETE SQL statement to remove the task matching the given ID
-	_, err := DB.Exec("DELETE FROM tasks WHERE id = ?", id)
+	if err := setTags(tx, int(id), t.Tags); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
 
-	// return any error encountered
-	return err
+	return int(id), tx.Commit()
 }
 
-// CompleteTask marks the task with the specified ID in the database as complete
-func CompleteTask(id int) error {
+// Update saves the title, due date, priority and tags of an existing task
+func (s *SQLiteStore) Update(t Task) error {
 
-	// get current date in layout YYYY-MM-DD
-	currentDate := time.Now().Format("2006-01-02")
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
 
-	// execute SQL statement to mark task as complete
-	// only update complete_date if field is NULL
-	_, err := DB.Exec(`
-		UPDATE tasks
-		SET complete = 1,
-		    complete_date = CASE
-		        WHEN complete_date IS NULL THEN ?
-		        ELSE complete_date
-		    END
-		WHERE id = ?
-	`, currentDate, id)
+	// replace the editable fields and tags for the task ID
+	if err := updateIn(tx, t, formatTimestamp(now())); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
 
-	// return any error encountered
-	return err
+	return tx.Commit()
 }
 
-// ReopenTask updates the task with the given ID to mark it as open (incomplete)
-// it sets the 'complete' field to false and clears complete_date
-func ReopenTask(id int) error {
+// handleTaken returns a function that reports whether a task in tx already has a handle
+func handleTaken(tx *sql.Tx) func(handle string) (bool, error) {
+	return func(handle string) (bool, error) {
+		var n int
+		err := tx.QueryRow("SELECT COUNT(*) FROM tasks WHERE handle = ?", handle).Scan(&n)
+		return n > 0, err
+	}
+}
 
-	// execute UPDATE SQL statement to set complete to false and clear completion date
-	_, err := DB.Exec("UPDATE tasks SET complete = 0, complete_date = NULL WHERE id = ?", id)
+// updateIn saves the title, due date, priority and tags of t within tx
+func updateIn(tx *sql.Tx, t Task, stamp string) error {
 
-	// return any error encountered
-	return err
+	// execute an UPDATE SQL statement to replace the editable fields for the task ID
+	if err := execIn(tx, updateStmt, t.ID, t.Title, t.Due, t.Priority, stamp, t.ID); err != nil {
+		return err
+	}
+
+	return setTags(tx, t.ID, t.Tags)
 }
 
-// GetTasks retrieves all tasks from the database and returns them as a slice of Task structs.
-// tasks are ordered by completion status, priority, presence of a due date, and due date ascending.
-func GetTasks() ([]Task, error) {
-
-	// SQL query to select all columns
-	query := `
-		SELECT id, title, due, complete, priority, complete_date
-		FROM tasks
-		ORDER BY
-		    complete ASC, -- incomplete tasks first, ASC puts false (0) before true (1)
-			priority DESC, -- among incomplete tasks, priority DESC puts priority tasks first
-			due IS NOT NULL DESC,  -- tasks with a due date come before tasks without a due date
-			due ASC  -- tasks are sorted by ascending due date, earliest first
-	`
+// setTags replaces the tags of the task with the given ID
+func setTags(tx *sql.Tx, id int, tags []string) error {
+	if _, err := tx.Exec("DELETE FROM task_tags WHERE task_id = ?", id); err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		if _, err := tx.Exec("INSERT OR IGNORE INTO task_tags (task_id, tag) VALUES (?, ?)", id, tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	// execute query and get each row
-	rows, err := DB.Query(query)
+// UpdateMany saves the title, due date, priority, tags and status of each task in a single transaction.
+// if any task cannot be saved, the transaction is rolled back and no tasks are changed
+func (s *SQLiteStore) UpdateMany(tasks []Task) error {
+	tx, err := s.db.Begin()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	// close rows
-	defer func(rows *sql.Rows) {
-		err := rows.Close()
-		if err != nil {
-			log.Printf("Failed to close rows: %v", err)
-			return
+	stamp := formatTimestamp(now())
+	currentDate := now().Format("2006-01-02")
+	for _, t := range tasks {
+		if err := updateIn(tx, t, stamp); err != nil {
+			_ = tx.Rollback()
+			return err
 		}
-	}(rows)
+		if err := execIn(tx, setStatusStmt, t.ID, setStatusArgs(t.ID, t.Status, currentDate, stamp)...); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
 
-	// slice to hold all retrieved tasks
-	var tasks []Task
+	return tx.Commit()
+}
 
-	// iterate over each row returned by query
-	for rows.Next() {
+// Renumber compacts task IDs into 1..N in a single transaction, as described by Renumbering.
+// the ID sequence is reset so the next task added is N+1, and tags move with their tasks
+func (s *SQLiteStore) Renumber(all bool) (map[int]int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
 
-		// create empty task struct
+	// read every task's ID and status, in ID order
+	rows, err := tx.Query("SELECT id, status FROM tasks ORDER BY id")
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	var tasks []Task
+	for rows.Next() {
 		var t Task
-
-		// scan the columns of the current row into the Task struct
-		err := rows.Scan(&t.ID, &t.Title, &t.Due, &t.Complete, &t.Priority, &t.CompleteDate)
-		if err != nil {
-			// return nil and error if scanning fails
+		if err := rows.Scan(&t.ID, &t.Status); err != nil {
+			_ = rows.Close()
+			_ = tx.Rollback()
 			return nil, err
 		}
-		// add the populated task struct to the tasks slice
 		tasks = append(tasks, t)
 	}
+	if err := rows.Close(); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
 
-	// return the slice of tasks, and return nil error on success
-	return tasks, nil
+	// move each task to the negative of its new ID first, so no two tasks ever share an ID, then flip the sign
+	changed := Renumbering(tasks, all)
+	for old, id := range changed {
+		for _, stmt := range []string{
+			"UPDATE tasks SET id = ? WHERE id = ?",
+			"UPDATE task_tags SET task_id = ? WHERE task_id = ?",
+		} {
+			if _, err := tx.Exec(stmt, -id, old); err != nil {
+				_ = tx.Rollback()
+				return nil, err
+			}
+		}
+	}
+	for _, stmt := range []string{
+		"UPDATE tasks SET id = -id WHERE id < 0",
+		"UPDATE task_tags SET task_id = -task_id WHERE task_id < 0",
+		"UPDATE sqlite_sequence SET seq = (SELECT IFNULL(MAX(id), 0) FROM tasks) WHERE name = 'tasks'",
+	} {
+		if _, err := tx.Exec(stmt); err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+
+	return changed, tx.Commit()
 }
 
-// SetDue updates the due date of the task identified by the given ID.
-// it sets the task's due field to the provided newDate string
-func SetDue(id int, newDate string) error {
+// Delete deletes the task with the specified ID from the database.
+func (s *SQLiteStore) Delete(id int) error {
 
-	// execute an UPDATE SQL statement to replace the due date for the specified task ID
-	_, err := DB.Exec("UPDATE tasks SET due = ? WHERE id = ?", newDate, id)
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
 
-	// return any encountered error
-	return err
+	// execute DELETE SQL statements to remove the task matching the given ID, and its tags
+	if _, err := tx.Exec("DELETE FROM task_tags WHERE task_id = ?", id); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := execIn(tx, "DELETE FROM tasks WHERE id = ?", id, id); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
 }
 
-// SetTitle updates the due date of the task identified by the given ID.
-// it sets the task's title field to the provided newTitle string
-func SetTitle(id int, newTitle string) error {
+// Complete marks the task with the specified ID in the database as complete, setting its status to done
+func (s *SQLiteStore) Complete(id int) error {
+	return s.SetStatus(id, StatusDone)
+}
 
-	// execute an UPDATE SQL statement to replace the title for the specified task ID
-	_, err := DB.Exec("UPDATE tasks SET title = ? WHERE id = ?", newTitle, id)
+// Reopen updates the task with the given ID to mark it as open (incomplete)
+// a done or cancelled task is set back to todo, other statuses are already open and are left unchanged
+func (s *SQLiteStore) Reopen(id int) error {
 
-	// return any encountered error
-	return err
+	// execute UPDATE SQL statement to set complete to false and clear completion date
+	return s.exec(`
+		UPDATE tasks
+		SET status = CASE
+		        WHEN status IN `+closedStatuses+` THEN ?
+		        ELSE status
+		    END,
+		    complete = 0,
+		    complete_date = NULL,
+		    updated_at = CASE
+		        WHEN status IN `+closedStatuses+` OR complete != 0 OR complete_date IS NOT NULL THEN ?
+		        ELSE updated_at
+		    END
+		WHERE id = ?
+	`, id, StatusTodo, formatTimestamp(now()), id)
 }
 
-// TogglePriority flips the priority status of the task identified by the given ID.
-func TogglePriority(id int) error {
+// SetStatus moves the task with the given ID to a new status.
+// the complete flag is kept in sync, so moving to done records today as the completion date if not already set,
+// and moving to any other status clears it
+func (s *SQLiteStore) SetStatus(id int, status string) error {
 
-	// execute an UPDATE SQL statement to flip the priority status for the specified task ID
-	_, err := DB.Exec("UPDATE tasks SET priority = NOT priority WHERE id = ?", id)
+	// get current date in layout YYYY-MM-DD
+	currentDate := now().Format("2006-01-02")
 
-	// return any encountered error
-	return err
+	// execute SQL statement to change status
+	return s.exec(setStatusStmt, id, setStatusArgs(id, status, currentDate, formatTimestamp(now()))...)
 }
 
-// SearchTasks searches the tasks database for tasks where the given keyword matches any of the specified fields
+// exec runs a statement that affects the task with the given ID,
+// returning a NotFoundError if no such task exists
+func (s *SQLiteStore) exec(stmt string, id int, args ...interface{}) error {
+	return execIn(s.db, stmt, id, args...)
+}
+
+// execer is implemented by both *sql.DB and *sql.Tx
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// execIn runs stmt with e, returning a NotFoundError for id if no rows were changed
+func execIn(e execer, stmt string, id int, args ...interface{}) error {
+	res, err := e.Exec(stmt, args...)
+	if err != nil {
+		return err
+	}
+
+	// no affected rows means no task had the ID
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return &NotFoundError{ID: id}
+	}
+	return nil
+}
+
+// List retrieves all tasks matching the filter from the database and returns them as a slice of Task structs.
+// tasks are ordered by completion status, priority, presence of a due date, and due date ascending.
+func (s *SQLiteStore) List(f Filter) ([]Task, error) {
+
+	// SQL query to select all columns of matching tasks
+	where, args := filterClause(f)
+	query := "SELECT " + taskColumns + " FROM tasks" + where + displayOrder
+
+	// execute query and get each row
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanTasks(rows)
+}
+
+// Search searches the tasks database for tasks where the query matches any of the fields enabled in the query.
+// each term is matched with the search_term function, so terms match as they do in MemoryStore. when the full-text
+// index is available, results are ranked by the relevance of their titles using bm25.
+// regular expression queries are matched against each field with REGEXP, and returned in display order.
 // Returns a slice of matching Task structs or an error if the query fails
-func SearchTasks(keyword string, searchID bool, searchTitle bool, searchDue bool) ([]Task, error) {
+func (s *SQLiteStore) Search(q Query) ([]Task, error) {
 
-	// conditions holds individual SQL WHERE clauses for each enabled search field
-	var conditions []string
+	// a query with no fields enabled matches nothing
+	if !q.ID && !q.Title && !q.Due {
+		return nil, nil
+	}
 
-	// args holds the arguments for the parameterised query placeholders
-	var args []interface{}
+	// regular expressions are matched by the REGEXP function instead
+	if q.Regexp {
+		return s.searchRegexp(q)
+	}
 
-	// if searching by ID, add a condition to match keyword against the ID cast as text
-	if searchID {
-		conditions = append(conditions, "CAST(id AS TEXT) LIKE ?")
-		args = append(args, "%"+keyword+"%")
+	// parse the search query
+	expr, err := ParseSearch(q.Keyword)
+	if err != nil {
+		return nil, err
 	}
 
-	// if searching by title, add a LIKE condition for the title column
-	if searchTitle {
-		conditions = append(conditions, "title LIKE ?")
-		args = append(args, "%"+keyword+"%")
+	// render the query as an SQL condition, matching each term against every enabled field
+	match, matchArgs := expr.sql(func(n *searchNode) (string, []interface{}) {
+
+		// conditions holds individual SQL WHERE clauses for each enabled search field
+		var conditions []string
+
+		// args holds the arguments for the parameterised query placeholders
+		var args []interface{}
+
+		// if searching by ID, add a condition to match term against the ID cast as text
+		if q.ID {
+			conditions = append(conditions, "search_term(?, ?, CAST(id AS TEXT))")
+			args = append(args, n.text, n.prefix)
+		}
+
+		// if searching by title, add a condition for the title column.
+		// the full-text index is not used here, as it only matches whole words
+		if q.Title {
+			conditions = append(conditions, "search_term(?, ?, title)")
+			args = append(args, n.text, n.prefix)
+		}
+
+		// searching by due date, add a condition for the due column
+		if q.Due {
+			conditions = append(conditions, "search_term(?, ?, COALESCE(due, ''))")
+			args = append(args, n.text, n.prefix)
+		}
+
+		return "(" + strings.Join(conditions, " OR ") + ")", args
+	})
+
+	// rank titles matching any wanted term with bm25, lower is more relevant
+	var join, order string
+	var joinArgs []interface{}
+	if terms := expr.positiveTerms(); q.Title && s.fts && len(terms) > 0 {
+		ftsTerms := make([]string, len(terms))
+		for i, n := range terms {
+			ftsTerms[i] = n.ftsString()
+		}
+		join = " LEFT JOIN (SELECT rowid AS fts_id, bm25(tasks_fts) AS rank FROM tasks_fts WHERE tasks_fts MATCH ?)" +
+			" AS ranked ON ranked.fts_id = tasks.id"
+		joinArgs = append(joinArgs, strings.Join(ftsTerms, " OR "))
+		order = "\n\t\tORDER BY ranked.rank IS NULL, ranked.rank," + displayOrderTerms
+	} else {
+		order = displayOrder
 	}
 
-	// searching by due date, add a LIKE condition for the due column
-	if searchDue {
-		conditions = append(conditions, "due LIKE ?")
-		args = append(args, "%"+keyword+"%")
+	// apply the filter constraints to the matching tasks
+	where, filterArgs := filterClause(q.Filter)
+	if where == "" {
+		where = " WHERE "
+	} else {
+		where += " AND "
 	}
 
-	// generate full query joining all conditions with OR
-	query := `
-		SELECT id, title, due, complete, priority
-		FROM tasks
-		WHERE ` + strings.Join(conditions, " OR ") + `
-		ORDER BY complete ASC, priority DESC, due IS NOT NULL DESC, due ASC`
+	// generate full query
+	query := "SELECT " + taskColumns + " FROM tasks" + join + where + match + order
 
 	// execute query and get rows, return nil with error if fails
-	rows, err := DB.Query(query, args...)
+	args := append(append(joinArgs, filterArgs...), matchArgs...)
+	rows, err := s.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
 
+	return scanTasks(rows)
+}
+
+// searchRegexp returns tasks where the regular expression in q.Keyword matches any of the fields enabled in q,
+// in display order
+func (s *SQLiteStore) searchRegexp(q Query) ([]Task, error) {
+
+	// check the pattern before SQLite sees it, so a bad pattern is reported clearly
+	if _, err := CompileRegexp(q.Keyword); err != nil {
+		return nil, err
+	}
+
+	// conditions holds a REGEXP condition for each enabled search field
+	var conditions []string
+	var matchArgs []interface{}
+	if q.ID {
+		conditions = append(conditions, "CAST(id AS TEXT) REGEXP ?")
+		matchArgs = append(matchArgs, q.Keyword)
+	}
+	if q.Title {
+		conditions = append(conditions, "title REGEXP ?")
+		matchArgs = append(matchArgs, q.Keyword)
+	}
+	if q.Due {
+		// REGEXP cannot be called with NULL, so skip tasks with no due date
+		conditions = append(conditions, "(IFNULL(due, '') != '' AND due REGEXP ?)")
+		matchArgs = append(matchArgs, q.Keyword)
+	}
+
+	// apply the filter constraints to the matching tasks
+	where, args := filterClause(q.Filter)
+	if where == "" {
+		where = " WHERE "
+	} else {
+		where += " AND "
+	}
+
+	// generate full query and execute it
+	query := "SELECT " + taskColumns + " FROM tasks" + where + "(" + strings.Join(conditions, " OR ") + ")" + displayOrder
+	rows, err := s.db.Query(query, append(args, matchArgs...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanTasks(rows)
+}
+
+// filterClause builds an SQL WHERE clause, and its arguments, that applies the filter's constraints.
+// it returns an empty clause if no constraints are enabled.
+func filterClause(f Filter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if f.Complete {
+		conditions = append(conditions, "status = '"+StatusDone+"'")
+	}
+	if f.Open {
+		conditions = append(conditions, "status NOT IN "+closedStatuses)
+	}
+	if f.Closed {
+		conditions = append(conditions, "status IN "+closedStatuses)
+	}
+	if f.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, f.Status)
+	}
+	if f.Priority {
+		conditions = append(conditions, "priority = 1")
+	}
+	if f.Normal {
+		conditions = append(conditions, "priority = 0")
+	}
+	if !f.UpdatedBefore.IsZero() {
+		conditions = append(conditions, "updated_at < ?")
+		args = append(args, formatTimestamp(f.UpdatedBefore))
+	}
+	if f.Tag != "" {
+		conditions = append(conditions, "id IN (SELECT task_id FROM task_tags WHERE tag = ?)")
+		args = append(args, f.Tag)
+	}
+	if f.DueFrom != "" || f.DueTo != "" {
+		conditions = append(conditions, "IFNULL(due, '') != ''")
+	}
+	if f.DueFrom != "" {
+		conditions = append(conditions, "due >= ?")
+		args = append(args, f.DueFrom)
+	}
+	if f.DueTo != "" {
+		conditions = append(conditions, "due <= ?")
+		args = append(args, f.DueTo)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// scanTasks reads every row selected with taskColumns into a slice of Task structs, closing rows when done
+func scanTasks(rows *sql.Rows) ([]Task, error) {
+
 	// close rows
 	defer func(rows *sql.Rows) {
 		err := rows.Close()
@@ -315,37 +681,55 @@ func SearchTasks(keyword string, searchID bool, searchTitle bool, searchDue bool
 		}
 	}(rows)
 
-	// slice to hold matched tasks
+	// slice to hold all retrieved tasks
 	var tasks []Task
 
-	// scan the columns of the current row into a Task struct
+	// iterate over each row returned by query
 	for rows.Next() {
+
+		// create empty task struct
 		var t Task
-		err := rows.Scan(&t.ID, &t.Title, &t.Due, &t.Complete, &t.Priority)
 
+		// due is nullable in the schema, so scan via NullString
+		var due sql.NullString
+
+		// timestamps are stored as text, and tags as a space separated list
+		var createdAt, updatedAt, tags string
+
+		// scan the columns of the current row into the Task struct
+		err := rows.Scan(&t.ID, &t.UUID, &t.Handle, &t.Title, &due, &t.Complete, &t.Priority, &t.CompleteDate,
+			&createdAt, &updatedAt, &t.Status, &tags)
 		if err != nil {
 			// return nil and error if scanning fails
 			return nil, err
 		}
+		t.Due = due.String
+		t.CreatedAt = parseTimestamp(createdAt)
+		t.UpdatedAt = parseTimestamp(updatedAt)
+		t.Tags = NormaliseTags(strings.Fields(tags))
 
-		// add Task struct to tasks slice
+		// add the populated task struct to the tasks slice
 		tasks = append(tasks, t)
 	}
 
-	// return task slice, and nil error on success
-	return tasks, nil
-
+	// return the slice of tasks, and any error encountered while iterating
+	return tasks, rows.Err()
 }
 
-// BackupDB creates a backup copy of the current SQLite database file ("tasks.db").
-// It reads the original database file and writes its contents to a new file "tasks.db.bak".
-func BackupDB() error {
+// Backup creates a backup copy of the SQLite database file (e.g. "tasks.db").
+// It reads the original database file and writes its contents to a new file (e.g. "tasks.db.bak").
+// In-memory databases are not backed up.
+func (s *SQLiteStore) Backup() error {
 
-	dbPath := getDBPath()
-	backupPath := dbPath + ".bak"
+	// nothing to back up for an ephemeral database
+	if s.path == MemoryPath {
+		return nil
+	}
+
+	backupPath := s.path + ".bak"
 
 	// read contents of current database file into memory
-	input, err := os.ReadFile(dbPath)
+	input, err := os.ReadFile(s.path)
 	if err != nil {
 		return err
 	}
@@ -354,15 +738,17 @@ func BackupDB() error {
 	return os.WriteFile(backupPath, input, 0644)
 }
 
-// RestoreBackup replaces the current database file with the backup copy.
+// Restore replaces the database file with its backup copy.
 // After successfully restoring, it deletes the backup file.
-func RestoreBackup() error {
+func (s *SQLiteStore) Restore() error {
 
-	// get path for DB
-	dbPath := getDBPath()
+	// in-memory databases are never backed up
+	if s.path == MemoryPath {
+		return ErrNoBackupInMemory
+	}
 
 	// get path for backup
-	backupPath := dbPath + ".bak"
+	backupPath := s.path + ".bak"
 
 	// read contents of backup database file into memory
 	input, err := os.ReadFile(backupPath)
@@ -371,7 +757,7 @@ func RestoreBackup() error {
 	}
 
 	// overwrite the contents of main database with backup contents
-	err = os.WriteFile(dbPath, input, 0644)
+	err = os.WriteFile(s.path, input, 0644)
 	if err != nil {
 		return err
 	}
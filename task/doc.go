@@ -1,5 +1,6 @@
 // Package task provides core functionality for manging tasks in TidyTask.
 //
-// It defines the Task struct, representing individual tasks.
-// It also handles all CRUD operations relating to tasks.
+// It defines the Task struct, representing individual tasks, and the Store interface
+// through which all CRUD operations relating to tasks are performed.
+// SQLiteStore is the database-backed implementation and MemoryStore an in-memory one for tests.
 package task
@@ -0,0 +1,111 @@
+package task
+
+import (
+	"slices"
+)
+
+// DryRunStore is a Store that plans changes without saving them.
+// It copies the tasks of another store into memory and makes every change there, so commands run as they
+// normally would, then Changes reports what would have changed. Backups are never taken.
+type DryRunStore struct {
+	*MemoryStore
+	path   string
+	before []Task // tasks when the dry run started, in display order
+
+	resetPlanned   bool
+	restorePlanned bool
+}
+
+// NewDryRunStore returns a DryRunStore holding a copy of the tasks in s. s itself is never changed
+func NewDryRunStore(s Store) (*DryRunStore, error) {
+	tasks, err := s.List(Filter{})
+	if err != nil {
+		return nil, err
+	}
+
+	m := NewMemoryStore()
+	m.load(tasks)
+
+	// number added tasks as s would, which skips the IDs of removed tasks
+	if m.nextID, err = s.NextID(); err != nil {
+		return nil, err
+	}
+	return &DryRunStore{MemoryStore: m, path: s.Path(), before: tasks}, nil
+}
+
+// Path returns the path of the store the tasks were copied from
+func (d *DryRunStore) Path() string {
+	return d.path
+}
+
+// Backup does nothing, as nothing is saved
+func (d *DryRunStore) Backup() error {
+	return nil
+}
+
+// Restore records that the backup would be restored, without reading it
+func (d *DryRunStore) Restore() error {
+	d.restorePlanned = true
+	return nil
+}
+
+// Reset records that every task and the backup would be deleted
+func (d *DryRunStore) Reset() error {
+	d.resetPlanned = true
+	return nil
+}
+
+// ResetPlanned reports whether Reset was called
+func (d *DryRunStore) ResetPlanned() bool {
+	return d.resetPlanned
+}
+
+// RestorePlanned reports whether Restore was called
+func (d *DryRunStore) RestorePlanned() bool {
+	return d.restorePlanned
+}
+
+// Change is a planned change to one task.
+// Before is nil for a task that would be added, and After is nil for a task that would be removed
+type Change struct {
+	Before *Task
+	After  *Task
+}
+
+// Changes returns every task that would be added, changed or removed, in display order, followed by the removed
+// tasks. tasks are matched by UUID, so a task that would be renumbered is a change rather than a removal
+func (d *DryRunStore) Changes() []Change {
+	before := make(map[string]Task, len(d.before))
+	for _, t := range d.before {
+		before[t.UUID] = t
+	}
+
+	after, _ := d.MemoryStore.List(Filter{})
+	kept := make(map[string]bool, len(after))
+
+	var changes []Change
+	for _, t := range after {
+		kept[t.UUID] = true
+		old, ok := before[t.UUID]
+		switch {
+		case !ok:
+			changes = append(changes, Change{After: &t})
+		case !sameTask(old, t):
+			changes = append(changes, Change{Before: &old, After: &t})
+		}
+	}
+	for _, t := range d.before {
+		if !kept[t.UUID] {
+			changes = append(changes, Change{Before: &t})
+		}
+	}
+
+	return changes
+}
+
+// sameTask reports whether two versions of a task have the same fields, ignoring when they were last updated
+func sameTask(a, b Task) bool {
+	return a.ID == b.ID && a.Title == b.Title && a.Due == b.Due && a.Status == b.Status &&
+		a.Complete == b.Complete && a.CompleteDate == b.CompleteDate && a.Priority == b.Priority &&
+		slices.Equal(a.Tags, b.Tags)
+}
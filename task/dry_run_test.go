@@ -0,0 +1,152 @@
+package task
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDryRunStoreLeavesStoreAlone(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			addTasks(t, store, Task{Title: "a", Tags: []string{"home"}}, Task{Title: "b"}, Task{Title: "c"})
+			before, err := store.List(Filter{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			d, err := NewDryRunStore(store)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.Path() != store.Path() {
+				t.Errorf("Path() = %q, want %q", d.Path(), store.Path())
+			}
+
+			// every kind of change is made to the copy
+			a, _ := d.Get(1)
+			a.Tags = append(a.Tags, "work")
+			a.Title = "a edited"
+			if err := d.Update(a); err != nil {
+				t.Fatal(err)
+			}
+			if err := d.Complete(2); err != nil {
+				t.Fatal(err)
+			}
+			if err := d.Delete(3); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := d.Add(Task{Title: "d"}); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := d.Renumber(true); err != nil {
+				t.Fatal(err)
+			}
+			for _, fn := range []func() error{d.Backup, d.Restore, d.Reset} {
+				if err := fn(); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			after, err := store.List(Filter{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(after, before) {
+				t.Errorf("store changed during a dry run:\nbefore %+v\nafter  %+v", before, after)
+			}
+			if err := store.Restore(); err == nil {
+				t.Error("dry run Backup took a backup")
+			}
+		})
+	}
+}
+
+func TestDryRunStoreNextID(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			addTasks(t, store, Task{Title: "a"}, Task{Title: "b"}, Task{Title: "c"})
+			if err := store.Delete(3); err != nil {
+				t.Fatal(err)
+			}
+
+			// the ID of a removed task is not reused, in a dry run or for real
+			d, err := NewDryRunStore(store)
+			if err != nil {
+				t.Fatal(err)
+			}
+			planned, err := d.Add(Task{Title: "d"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			id, err := store.Add(Task{Title: "d"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if planned != 4 || id != 4 {
+				t.Errorf("dry run added task %d and store added task %d, want 4", planned, id)
+			}
+		})
+	}
+}
+
+func TestDryRunChanges(t *testing.T) {
+	store := NewMemoryStore()
+	addTasks(t, store, Task{Title: "a"}, Task{Title: "b"}, Task{Title: "c"}, Task{Title: "d"})
+
+	d, err := NewDryRunStore(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changes := d.Changes(); len(changes) != 0 {
+		t.Errorf("changes before any change = %+v", changes)
+	}
+
+	// a task updated to the same values is not a change
+	b, _ := d.Get(2)
+	if err := d.Update(b); err != nil {
+		t.Fatal(err)
+	}
+	if changes := d.Changes(); len(changes) != 0 {
+		t.Errorf("changes after an update to the same values = %+v", changes)
+	}
+
+	if err := d.SetStatus(3, StatusDoing); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Delete(1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Add(Task{Title: "e"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// tasks keep their UUIDs when renumbered, so a new ID is a change rather than a removal and an addition
+	if _, err := d.Renumber(true); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, c := range d.Changes() {
+		switch {
+		case c.Before == nil:
+			got = append(got, "add "+c.After.Title)
+		case c.After == nil:
+			got = append(got, "remove "+c.Before.Title)
+		default:
+			got = append(got, "change "+c.Before.Title+" → "+c.After.Title)
+		}
+	}
+	want := []string{"change b → b", "change c → c", "change d → d", "add e", "remove a"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("changes = %q, want %q", got, want)
+	}
+
+	if d.ResetPlanned() || d.RestorePlanned() {
+		t.Error("reset or restore planned without being called")
+	}
+	_ = d.Restore()
+	_ = d.Reset()
+	if !d.ResetPlanned() || !d.RestorePlanned() {
+		t.Error("reset and restore not planned")
+	}
+}
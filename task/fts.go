@@ -0,0 +1,95 @@
+package task
+
+import (
+	"database/sql"
+)
+
+// ftsTriggers keep the tasks_fts full-text index in sync with the tasks table
+var ftsTriggers = map[string]string{
+	"tasks_fts_ai": `CREATE TRIGGER tasks_fts_ai AFTER INSERT ON tasks BEGIN
+		INSERT INTO tasks_fts(rowid, title) VALUES (new.id, new.title);
+	END`,
+	"tasks_fts_ad": `CREATE TRIGGER tasks_fts_ad AFTER DELETE ON tasks BEGIN
+		INSERT INTO tasks_fts(tasks_fts, rowid, title) VALUES ('delete', old.id, old.title);
+	END`,
+	"tasks_fts_au": `CREATE TRIGGER tasks_fts_au AFTER UPDATE OF id, title ON tasks BEGIN
+		INSERT INTO tasks_fts(tasks_fts, rowid, title) VALUES ('delete', old.id, old.title);
+		INSERT INTO tasks_fts(rowid, title) VALUES (new.id, new.title);
+	END`,
+}
+
+// setupFTS creates the tasks_fts full-text index over task titles, if this build of SQLite includes FTS5.
+// it returns true if the index is available.
+//
+// FTS5 is only compiled into go-sqlite3 with the sqlite_fts5 build tag. When a database that has an index is
+// opened by a build without FTS5, the triggers are dropped so tasks can still be changed, and the index is
+// rebuilt the next time a build with FTS5 opens it.
+func setupFTS(db *sql.DB) (bool, error) {
+
+	// check whether this build includes FTS5
+	var available bool
+	if err := db.QueryRow("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&available); err != nil {
+		return false, err
+	}
+
+	if !available {
+		// FTS5 is unavailable, drop triggers that would fail on every change
+		for name := range ftsTriggers {
+			if _, err := db.Exec("DROP TRIGGER IF EXISTS " + name); err != nil {
+				return false, err
+			}
+		}
+		return false, nil
+	}
+
+	// create the index, an external content table that reads titles from tasks
+	_, err := db.Exec(`CREATE VIRTUAL TABLE IF NOT EXISTS tasks_fts USING fts5(title, content='tasks', content_rowid='id')`)
+	if err != nil {
+		return false, err
+	}
+
+	// check whether the triggers exist as defined above, if any are missing or out of date the index may be too
+	rows, err := db.Query(`SELECT name, sql FROM sqlite_master WHERE type = 'trigger' AND name LIKE 'tasks_fts_%'`)
+	if err != nil {
+		return false, err
+	}
+	current := 0
+	for rows.Next() {
+		var name, stmt string
+		if err := rows.Scan(&name, &stmt); err != nil {
+			_ = rows.Close()
+			return false, err
+		}
+		if ftsTriggers[name] == stmt {
+			current++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+	if current == len(ftsTriggers) {
+		return true, nil
+	}
+
+	// recreate the triggers and rebuild the index in one transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return false, err
+	}
+	for name, stmt := range ftsTriggers {
+		if _, err := tx.Exec("DROP TRIGGER IF EXISTS " + name); err != nil {
+			_ = tx.Rollback()
+			return false, err
+		}
+		if _, err := tx.Exec(stmt); err != nil {
+			_ = tx.Rollback()
+			return false, err
+		}
+	}
+	if _, err := tx.Exec(`INSERT INTO tasks_fts(tasks_fts) VALUES ('rebuild')`); err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
@@ -0,0 +1,43 @@
+package task
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSetupFTSReplacesOutdatedTriggers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.db")
+	store, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !store.fts {
+		_ = store.Close()
+		t.Skip("build without sqlite_fts5")
+	}
+
+	// put back the update trigger of an older version, which ran on every change to a task
+	_, err = store.db.Exec(`DROP TRIGGER tasks_fts_au;
+		CREATE TRIGGER tasks_fts_au AFTER UPDATE ON tasks BEGIN
+			INSERT INTO tasks_fts(tasks_fts, rowid, title) VALUES ('delete', old.id, old.title);
+			INSERT INTO tasks_fts(rowid, title) VALUES (new.id, new.title);
+		END`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = store.Close()
+
+	store, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+
+	var stmt string
+	if err := store.db.QueryRow(`SELECT sql FROM sqlite_master WHERE name = 'tasks_fts_au'`).Scan(&stmt); err != nil {
+		t.Fatal(err)
+	}
+	if stmt != ftsTriggers["tasks_fts_au"] {
+		t.Errorf("trigger was not replaced:\n%s", stmt)
+	}
+}
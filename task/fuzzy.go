@@ -0,0 +1,149 @@
+package task
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// DefaultFuzzyThreshold is the lowest score a task needs to be returned by a fuzzy search
+const DefaultFuzzyThreshold = 0.6
+
+// FuzzyMatch is a task found by a fuzzy search, with a score between 0 and 1
+type FuzzyMatch struct {
+	Task  Task
+	Score float64
+}
+
+// FuzzySearch scores every task matching q.Filter against q.Keyword, allowing for typos and missing letters.
+// each field enabled in q is scored and the best score is kept. tasks scoring at least threshold are returned,
+// best match first.
+func FuzzySearch(s Store, q Query, threshold float64) ([]FuzzyMatch, error) {
+
+	// a query with no fields enabled matches nothing
+	if !q.ID && !q.Title && !q.Due {
+		return nil, nil
+	}
+
+	// fuzzy matching is done in Go, so get every candidate task
+	tasks, err := s.List(q.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []FuzzyMatch
+	for _, t := range tasks {
+		var score float64
+		if q.ID {
+			score = max(score, FuzzyScore(q.Keyword, strconv.Itoa(t.ID)))
+		}
+		if q.Title {
+			score = max(score, FuzzyScore(q.Keyword, t.Title))
+		}
+		if q.Due {
+			score = max(score, FuzzyScore(q.Keyword, t.Due))
+		}
+
+		if score >= threshold {
+			matches = append(matches, FuzzyMatch{Task: t, Score: score})
+		}
+	}
+
+	// best match first, tasks are already in display order so keep it for equal scores
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+
+	return matches, nil
+}
+
+// FuzzyScore returns how closely text matches query, from 0 for no match to 1 for an exact match.
+// each word of the query is compared with its closest word in text, and the scores are averaged,
+// so "dploy stagng" scores highly against "Deploy to staging".
+func FuzzyScore(query, text string) float64 {
+	queryWords := fuzzyWords(query)
+	textWords := fuzzyWords(text)
+	if len(queryWords) == 0 || len(textWords) == 0 {
+		return 0
+	}
+
+	var total float64
+	for _, q := range queryWords {
+		var best float64
+		for _, w := range textWords {
+			best = max(best, wordScore(q, w))
+		}
+		total += best
+	}
+
+	return total / float64(len(queryWords))
+}
+
+// fuzzyWords splits s into lower-case words of letters and digits
+func fuzzyWords(s string) [][]rune {
+	var words [][]rune
+	for _, f := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		words = append(words, []rune(f))
+	}
+	return words
+}
+
+// wordScore compares a query word with a word of text.
+// typos are scored by edit distance, and abbreviations such as "stg" for "staging" by being a subsequence
+func wordScore(q, w []rune) float64 {
+
+	// similarity from edit distance, 1 when the words are the same
+	longest := max(len(q), len(w))
+	score := 1 - float64(levenshtein(q, w))/float64(longest)
+
+	// a query word whose letters all appear in order in the word is a likely abbreviation,
+	// scored higher the more of the word it covers. a match from the first letter is likelier still
+	if isSubsequence(q, w) {
+		sub := 0.5 + 0.4*float64(len(q))/float64(len(w))
+		if q[0] == w[0] {
+			sub += 0.1 * float64(len(q)) / float64(len(w))
+		}
+		score = max(score, sub)
+	}
+
+	return score
+}
+
+// levenshtein returns the number of single letter insertions, deletions and substitutions to turn a into b
+func levenshtein(a, b []rune) int {
+
+	// only the previous row of the distance matrix is needed
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+// isSubsequence reports whether the letters of q all appear in w, in the same order
+func isSubsequence(q, w []rune) bool {
+	i := 0
+	for _, r := range w {
+		if i < len(q) && q[i] == r {
+			i++
+		}
+	}
+	return i == len(q)
+}
@@ -0,0 +1,101 @@
+package task
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"deploy", "deploy", 0},
+		{"dploy", "deploy", 1},
+		{"stagng", "staging", 1},
+		{"kitten", "sitting", 3},
+		{"café", "cafe", 1},
+	}
+	for _, tt := range tests {
+		if got := levenshtein([]rune(tt.a), []rune(tt.b)); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		query, text string
+		min, max    float64
+	}{
+		{"deploy", "Deploy to staging", 1, 1},
+		{"DEPLOY STAGING", "deploy to staging", 1, 1},
+		{"dploy stagng", "Deploy to staging", 0.9, 0.95},
+		{"stg", "Deploy to staging", 0.7, 0.75},
+		{"milk", "Deploy to staging", 0, 0.5},
+		{"", "Deploy", 0, 0},
+		{"deploy", "", 0, 0},
+		{"--", "Deploy", 0, 0},
+	}
+	for _, tt := range tests {
+		got := FuzzyScore(tt.query, tt.text)
+		if got < tt.min-1e-9 || got > tt.max+1e-9 {
+			t.Errorf("FuzzyScore(%q, %q) = %.3f, want between %.2f and %.2f", tt.query, tt.text, got, tt.min, tt.max)
+		}
+	}
+
+	// an abbreviation from the first letter beats one from the middle of a word
+	if a, b := FuzzyScore("stg", "staging"), FuzzyScore("tgn", "staging"); a <= b {
+		t.Errorf("FuzzyScore(stg) = %.3f, not above FuzzyScore(tgn) = %.3f", a, b)
+	}
+}
+
+func TestFuzzySearch(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			addTasks(t, store,
+				Task{Title: "Write deploy docs"},
+				Task{Title: "Deploy to staging"},
+				Task{Title: "Buy milk", Due: "2030-01-02"},
+			)
+
+			matches, err := FuzzySearch(store, Query{Keyword: "dploy stagng", Title: true}, DefaultFuzzyThreshold)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []int
+			for _, m := range matches {
+				got = append(got, m.Task.ID)
+				if m.Score < DefaultFuzzyThreshold || m.Score > 1 || math.IsNaN(m.Score) {
+					t.Errorf("task %d has score %v", m.Task.ID, m.Score)
+				}
+			}
+			if !reflect.DeepEqual(got, []int{2}) {
+				t.Errorf("got IDs %v, want [2]", got)
+			}
+
+			// a lower threshold lets in weaker matches, best first
+			matches, err = FuzzySearch(store, Query{Keyword: "deploy staging", Title: true}, 0.4)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(matches) < 2 || matches[0].Task.ID != 2 || matches[1].Task.ID != 1 {
+				t.Errorf("matches = %+v, want task 2 then task 1", matches)
+			}
+
+			// only enabled fields are scored
+			matches, err = FuzzySearch(store, Query{Keyword: "2030-01-02", Title: true}, DefaultFuzzyThreshold)
+			if err != nil || len(matches) != 0 {
+				t.Errorf("title search for a due date = %+v, %v", matches, err)
+			}
+			matches, err = FuzzySearch(store, Query{Keyword: "2030-01-02", Due: true}, DefaultFuzzyThreshold)
+			if err != nil || len(matches) != 1 || matches[0].Task.ID != 3 {
+				t.Errorf("due search = %+v, %v", matches, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package task
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// MinHandleLength is the length of most handles, longer handles are only chosen when shorter ones are taken
+const MinHandleLength = 4
+
+// handleAlphabet is Crockford's base32 alphabet in lower case, it has no i, l, o or u so handles are easy to read.
+// handles always contain a letter, so they can never be mistaken for an integer ID
+const handleAlphabet = "0123456789" + handleLetters
+
+// handleLetters are the letters of handleAlphabet
+const handleLetters = "abcdefghjkmnpqrstvwxyz"
+
+// handleEncoding encodes UUIDs for handles
+var handleEncoding = base32.NewEncoding(handleAlphabet).WithPadding(base32.NoPadding)
+
+// newUUID returns a random version 4 UUID in its canonical text form
+func newUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate UUID: %w", err)
+	}
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// newIdentity returns a new UUID and the handle chosen for it.
+// taken reports whether a handle is already used by another task
+func newIdentity(taken func(handle string) (bool, error)) (string, string, error) {
+	uuid, err := newUUID()
+	if err != nil {
+		return "", "", err
+	}
+	handle, err := chooseHandle(uuid, taken)
+	if err != nil {
+		return "", "", err
+	}
+	return uuid, handle, nil
+}
+
+// chooseHandle returns the shortest prefix of the encoded UUID, of at least MinHandleLength characters,
+// that contains a letter and is not taken. handles are stored, so they never change once chosen
+func chooseHandle(uuid string, taken func(handle string) (bool, error)) (string, error) {
+	raw, err := hex.DecodeString(strings.ReplaceAll(uuid, "-", ""))
+	if err != nil {
+		return "", fmt.Errorf("invalid UUID %q: %w", uuid, err)
+	}
+	encoded := handleEncoding.EncodeToString(raw)
+
+	// the full encoding is used in the unlikely event that every shorter prefix is taken
+	for n := MinHandleLength; n <= len(encoded); n++ {
+		handle := encoded[:n]
+		if !strings.ContainsAny(handle, handleLetters) && n < len(encoded) {
+			continue
+		}
+		used, err := taken(handle)
+		if err != nil {
+			return "", err
+		}
+		if !used {
+			return handle, nil
+		}
+	}
+	return "", fmt.Errorf("no free handle for UUID %s", uuid)
+}
+
+// IsHandle reports whether s is written like a task handle, it does not check that any task has the handle
+func IsHandle(s string) bool {
+	if len(s) < MinHandleLength || !strings.ContainsAny(s, handleLetters) {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune(handleAlphabet, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsUUID reports whether s is written like a task UUID
+func IsUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case i == 8 || i == 13 || i == 18 || i == 23:
+			if r != '-' {
+				return false
+			}
+		case !strings.ContainsRune("0123456789abcdef", r):
+			return false
+		}
+	}
+	return true
+}
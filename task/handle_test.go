@@ -0,0 +1,185 @@
+package task
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewUUID(t *testing.T) {
+	seen := make(map[string]bool)
+	for range 100 {
+		uuid, err := newUUID()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !IsUUID(uuid) || uuid[14] != '4' || !strings.ContainsRune("89ab", rune(uuid[19])) {
+			t.Errorf("newUUID() = %s, not a version 4 UUID", uuid)
+		}
+		if seen[uuid] {
+			t.Errorf("newUUID() repeated %s", uuid)
+		}
+		seen[uuid] = true
+	}
+}
+
+func TestChooseHandle(t *testing.T) {
+	uuid := "0a1b2c3d-4e5f-4a6b-8c7d-8e9fa0b1c2d3"
+	free := func(string) (bool, error) { return false, nil }
+
+	handle, err := chooseHandle(uuid, free)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(handle) != MinHandleLength || !IsHandle(handle) {
+		t.Errorf("chooseHandle = %q, want a handle of %d characters", handle, MinHandleLength)
+	}
+
+	// the same UUID always gives the same handle
+	if again, _ := chooseHandle(uuid, free); again != handle {
+		t.Errorf("chooseHandle gave %q then %q", handle, again)
+	}
+
+	// a taken handle is lengthened, keeping the same prefix
+	longer, err := chooseHandle(uuid, func(h string) (bool, error) { return h == handle, nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(longer) != MinHandleLength+1 || !strings.HasPrefix(longer, handle) {
+		t.Errorf("chooseHandle with %q taken = %q", handle, longer)
+	}
+
+	// prefixes without a letter are skipped, so handles never look like IDs
+	zeros := "00000000-4e5f-4a6b-8c7d-8e9fa0b1c2d3"
+	handle, err = chooseHandle(zeros, free)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsHandle(handle) || len(handle) <= MinHandleLength || !strings.HasPrefix(handle, "000000") {
+		t.Errorf("chooseHandle(%s) = %q", zeros, handle)
+	}
+
+	if _, err := chooseHandle(uuid, func(string) (bool, error) { return true, nil }); err == nil {
+		t.Error("expected an error when every handle is taken")
+	}
+	if _, err := chooseHandle("not-a-uuid", free); err == nil {
+		t.Error("expected an error for an invalid UUID")
+	}
+}
+
+func TestIsHandle(t *testing.T) {
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"k3fa", true},
+		{"0000a", true},
+		{"abcdefghjkmnpqrstvwxyz", true},
+		{"k3f", false},
+		{"1234", false},
+		{"K3FA", false},
+		{"k3fi", false},
+		{"k3-fa", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsHandle(tt.s); got != tt.want {
+			t.Errorf("IsHandle(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestIsUUID(t *testing.T) {
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"0a1b2c3d-4e5f-4a6b-8c7d-8e9fa0b1c2d3", true},
+		{"0A1B2C3D-4E5F-4A6B-8C7D-8E9FA0B1C2D3", false},
+		{"0a1b2c3d4e5f4a6b8c7d8e9fa0b1c2d3", false},
+		{"0a1b2c3d-4e5f-4a6b-8c7d-8e9fa0b1c2dz", false},
+		{"0a1b2c3d-4e5f-4a6b-8c7d_8e9fa0b1c2d3", false},
+		{"k3fa", false},
+	}
+	for _, tt := range tests {
+		if got := IsUUID(tt.s); got != tt.want {
+			t.Errorf("IsUUID(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestStoreIdentity(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			addTasks(t, store, Task{Title: "a"}, Task{Title: "b"}, Task{Title: "c"})
+			before := identities(t, store)
+
+			// each task has its own handle
+			handles := make(map[string]bool)
+			for _, id := range before {
+				handle := strings.Fields(id)[1]
+				if handles[handle] {
+					t.Errorf("handle %s used twice", handle)
+				}
+				handles[handle] = true
+			}
+
+			// the UUID and handle of a task never change, even when its ID does
+			if err := store.Update(Task{ID: 2, Title: "b edited", UUID: "changed", Handle: "zzzz"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := store.Delete(1); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := store.Renumber(true); err != nil {
+				t.Fatal(err)
+			}
+			after := identities(t, store)
+			if after["b edited"] != before["b"] {
+				t.Errorf("identity of b changed from %q to %q", before["b"], after["b edited"])
+			}
+			if after["c"] != before["c"] {
+				t.Errorf("identity of c changed from %q to %q", before["c"], after["c"])
+			}
+		})
+	}
+}
+
+// identities returns the UUID and handle of every task in store, separated by a space, by title
+func identities(t *testing.T, store Store) map[string]string {
+	t.Helper()
+	tasks, err := store.List(Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := make(map[string]string, len(tasks))
+	for _, tk := range tasks {
+		out[tk.Title] = tk.UUID + " " + tk.Handle
+	}
+	return out
+}
+
+func TestMigrateUUIDs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.db")
+	createVersion(t, path, 4,
+		`INSERT INTO tasks (title) VALUES ('first')`,
+		`INSERT INTO tasks (title) VALUES ('second')`,
+	)
+
+	store := openVersion(t, path)
+	tasks, err := store.List(Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("got %d tasks, want 2", len(tasks))
+	}
+	for _, tk := range tasks {
+		if !IsUUID(tk.UUID) || !IsHandle(tk.Handle) {
+			t.Errorf("upgraded task %d has UUID %q and handle %q", tk.ID, tk.UUID, tk.Handle)
+		}
+	}
+	if tasks[0].UUID == tasks[1].UUID || tasks[0].Handle == tasks[1].Handle {
+		t.Errorf("upgraded tasks share an identity: %+v", tasks)
+	}
+}
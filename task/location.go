@@ -0,0 +1,111 @@
+package task
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// LocalDirName is the name of the directory that marks a project-local database, similar to .git
+	LocalDirName = ".tidytask"
+
+	// LocalFileName is the name of a single-file project-local database
+	LocalFileName = ".tidytask.db"
+
+	// ScopeGlobal identifies the per-user database stored in the config directory
+	ScopeGlobal = "global"
+
+	// ScopeProject identifies a project-local database discovered from the working directory
+	ScopeProject = "project"
+
+	// ScopeCustom identifies a database chosen with the --db flag or the TIDYTASK_DB environment variable
+	ScopeCustom = "custom"
+
+	// EnvDB is the environment variable that overrides the database path
+	EnvDB = "TIDYTASK_DB"
+)
+
+// FindLocalDB searches dir and each of its parents for a project-local database.
+// A ".tidytask" directory takes precedence over a ".tidytask.db" file in the same directory.
+// It returns the path to the database file and true if one was found.
+func FindLocalDB(dir string) (string, bool) {
+
+	// make path absolute so walking up the tree terminates at the filesystem root
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+
+	for {
+		// check for a .tidytask directory
+		if info, err := os.Stat(filepath.Join(dir, LocalDirName)); err == nil && info.IsDir() {
+			return filepath.Join(dir, LocalDirName, "tasks.db"), true
+		}
+
+		// check for a .tidytask.db file
+		if info, err := os.Stat(filepath.Join(dir, LocalFileName)); err == nil && !info.IsDir() {
+			return filepath.Join(dir, LocalFileName), true
+		}
+
+		// move to parent, stop once the root has been checked
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// ResolveDBPath returns the path of the database commands should operate on, and its scope.
+// The explicit path (from --db) takes precedence, followed by the TIDYTASK_DB environment variable.
+// Otherwise, unless global is set, a project-local database found from the working directory is preferred
+// over the per-user database.
+func ResolveDBPath(explicit string, global bool) (string, string, error) {
+
+	// an explicitly requested database always wins
+	if explicit != "" {
+		return explicit, ScopeCustom, nil
+	}
+	if env := os.Getenv(EnvDB); env != "" {
+		return env, ScopeCustom, nil
+	}
+
+	// look for a project-local database
+	if !global {
+		if wd, err := os.Getwd(); err == nil {
+			if path, ok := FindLocalDB(wd); ok {
+				return path, ScopeProject, nil
+			}
+		}
+	}
+
+	// fall back to the per-user database
+	path, err := getDBPath()
+	if err != nil {
+		return "", "", err
+	}
+	return path, ScopeGlobal, nil
+}
+
+// CreateLocalDB creates a ".tidytask" directory in dir and returns the path of the database file inside it.
+// It returns an error if dir already contains a project-local database.
+func CreateLocalDB(dir string) (string, error) {
+
+	localDir := filepath.Join(dir, LocalDirName)
+
+	// refuse to initialise twice in the same directory
+	if _, err := os.Stat(localDir); err == nil {
+		return "", fmt.Errorf("%s already exists in %s", LocalDirName, dir)
+	}
+	if _, err := os.Stat(filepath.Join(dir, LocalFileName)); err == nil {
+		return "", fmt.Errorf("%s already exists in %s", LocalFileName, dir)
+	}
+
+	// create the directory that marks the project
+	if err := os.Mkdir(localDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", LocalDirName, err)
+	}
+
+	return filepath.Join(localDir, "tasks.db"), nil
+}
@@ -0,0 +1,149 @@
+package task
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// makeTree creates each path below root, as a directory if it ends in a slash, otherwise as an empty file
+func makeTree(t *testing.T, root string, paths ...string) {
+	t.Helper()
+	for _, p := range paths {
+		full := filepath.Join(root, p)
+		if p[len(p)-1] == '/' {
+			if err := os.MkdirAll(full, 0755); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFindLocalDB(t *testing.T) {
+	tests := []struct {
+		name  string
+		tree  []string
+		start string
+		want  string // relative to the root, empty when nothing is found
+	}{
+		{"directory", []string{".tidytask/"}, ".", ".tidytask/tasks.db"},
+		{"file", []string{".tidytask.db"}, ".", ".tidytask.db"},
+		{"directory beats file", []string{".tidytask/", ".tidytask.db"}, ".", ".tidytask/tasks.db"},
+		{"walks up to a parent", []string{".tidytask/", "src/pkg/"}, "src/pkg", ".tidytask/tasks.db"},
+		{"nearest project wins", []string{".tidytask.db", "sub/.tidytask/"}, "sub", "sub/.tidytask/tasks.db"},
+		{"file named like the directory", []string{".tidytask", "sub/"}, "sub", ""},
+		{"directory named like the file", []string{".tidytask.db/"}, ".", ""},
+		{"nothing found", []string{"src/"}, "src", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			makeTree(t, root, tt.tree...)
+
+			got, ok := FindLocalDB(filepath.Join(root, tt.start))
+			if tt.want == "" {
+				if ok {
+					t.Errorf("FindLocalDB found %s, want nothing", got)
+				}
+				return
+			}
+			if want := filepath.Join(root, tt.want); !ok || got != want {
+				t.Errorf("FindLocalDB = %s, %v; want %s", got, ok, want)
+			}
+		})
+	}
+}
+
+func TestCreateLocalDB(t *testing.T) {
+	tests := []struct {
+		name    string
+		tree    []string
+		wantErr bool
+	}{
+		{"empty directory", nil, false},
+		{"project in a parent", []string{"../.tidytask/"}, false},
+		{"already initialised", []string{".tidytask/"}, true},
+		{"single-file database", []string{".tidytask.db"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "project")
+			makeTree(t, dir, append([]string{"./"}, tt.tree...)...)
+
+			path, err := CreateLocalDB(dir)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("CreateLocalDB = %s, want an error", path)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := filepath.Join(dir, ".tidytask", "tasks.db"); path != want {
+				t.Errorf("CreateLocalDB = %s, want %s", path, want)
+			}
+			if found, ok := FindLocalDB(dir); !ok || found != path {
+				t.Errorf("FindLocalDB after CreateLocalDB = %s, %v; want %s", found, ok, path)
+			}
+		})
+	}
+}
+
+func TestResolveDBPath(t *testing.T) {
+	root := t.TempDir()
+	config := filepath.Join(root, "config")
+	t.Setenv("XDG_CONFIG_HOME", config)
+	globalPath := filepath.Join(config, "tidytask", "tasks.db")
+
+	project := filepath.Join(root, "project")
+	makeTree(t, project, ".tidytask/", "src/")
+	projectPath := filepath.Join(project, ".tidytask", "tasks.db")
+
+	tests := []struct {
+		name      string
+		dir       string
+		env       string
+		explicit  string
+		global    bool
+		wantPath  string
+		wantScope string
+	}{
+		{"global outside a project", root, "", "", false, globalPath, ScopeGlobal},
+		{"project from a subdirectory", filepath.Join(project, "src"), "", "", false, projectPath, ScopeProject},
+		{"--global skips the project", project, "", "", true, globalPath, ScopeGlobal},
+		{"environment beats the project", project, "env.db", "", false, "env.db", ScopeCustom},
+		{"environment beats --global", project, "env.db", "", true, "env.db", ScopeCustom},
+		{"--db beats the environment", project, "env.db", "flag.db", false, "flag.db", ScopeCustom},
+		{"--db beats --global", root, "", "flag.db", true, "flag.db", ScopeCustom},
+		{"in memory", project, "", MemoryPath, false, MemoryPath, ScopeCustom},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(tt.dir)
+			t.Setenv(EnvDB, tt.env)
+
+			path, scope, err := ResolveDBPath(tt.explicit, tt.global)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if path != tt.wantPath || scope != tt.wantScope {
+				t.Errorf("ResolveDBPath = %s (%s), want %s (%s)", path, scope, tt.wantPath, tt.wantScope)
+			}
+		})
+	}
+}
+
+func TestOpenMissingDirectory(t *testing.T) {
+	if store, err := Open(filepath.Join(t.TempDir(), "missing", "tasks.db")); err == nil {
+		_ = store.Close()
+		t.Error("expected an error when the directory does not exist")
+	}
+}
@@ -0,0 +1,287 @@
+package task
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+)
+
+// MemoryStore is a Store that keeps tasks in memory, it is intended for tests.
+// Backups are kept in memory too, so Backup and Restore behave like the SQLite implementation.
+type MemoryStore struct {
+	tasks  map[int]Task
+	nextID int
+
+	// backup is a snapshot of tasks and nextID taken by Backup, nil when no backup exists
+	backup     map[int]Task
+	backupNext int
+}
+
+// NewMemoryStore returns an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{tasks: make(map[int]Task), nextID: 1}
+}
+
+// Path returns MemoryPath, as tasks are never written to disk
+func (m *MemoryStore) Path() string {
+	return MemoryPath
+}
+
+// NextID returns the ID the next added task will be given
+func (m *MemoryStore) NextID() (int, error) {
+	return m.nextID, nil
+}
+
+// Close does nothing, tasks remain available until the store is discarded
+func (m *MemoryStore) Close() error {
+	return nil
+}
+
+// Add stores a new task, assigning it the next ID, a UUID and a handle
+func (m *MemoryStore) Add(t Task) (int, error) {
+	uuid, handle, err := newIdentity(func(handle string) (bool, error) {
+		for _, existing := range m.tasks {
+			if existing.Handle == handle {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	t.ID = m.nextID
+	t.UUID, t.Handle = uuid, handle
+	if t.Status == "" || IsClosedStatus(t.Status) {
+		t.Status = StatusTodo
+	}
+	t.Complete = false
+	t.CompleteDate = sql.NullString{}
+	t.Tags = NormaliseTags(t.Tags)
+	t.CreatedAt = timestamp()
+	t.UpdatedAt = t.CreatedAt
+	m.tasks[t.ID] = t
+	m.nextID++
+	return t.ID, nil
+}
+
+// Get returns the task with the given ID, or a NotFoundError
+func (m *MemoryStore) Get(id int) (Task, error) {
+	t, ok := m.tasks[id]
+	if !ok {
+		return Task{}, &NotFoundError{ID: id}
+	}
+	return t, nil
+}
+
+// List returns all tasks matching the filter in display order
+func (m *MemoryStore) List(f Filter) ([]Task, error) {
+	var tasks []Task
+	for _, t := range m.tasks {
+		if f.Match(t) {
+			tasks = append(tasks, t)
+		}
+	}
+	sortTasks(tasks)
+	return tasks, nil
+}
+
+// Update saves the title, due date, priority and tags of an existing task
+func (m *MemoryStore) Update(t Task) error {
+	existing, ok := m.tasks[t.ID]
+	if !ok {
+		return &NotFoundError{ID: t.ID}
+	}
+	existing.Title = t.Title
+	existing.Due = t.Due
+	existing.Priority = t.Priority
+	existing.Tags = NormaliseTags(t.Tags)
+	existing.UpdatedAt = timestamp()
+	m.tasks[t.ID] = existing
+	return nil
+}
+
+// Delete removes the task with the given ID
+func (m *MemoryStore) Delete(id int) error {
+	if _, ok := m.tasks[id]; !ok {
+		return &NotFoundError{ID: id}
+	}
+	delete(m.tasks, id)
+	return nil
+}
+
+// Complete marks the task as done, keeping any existing completion date
+func (m *MemoryStore) Complete(id int) error {
+	return m.SetStatus(id, StatusDone)
+}
+
+// Reopen marks a done or cancelled task as todo and clears its completion date
+func (m *MemoryStore) Reopen(id int) error {
+	t, ok := m.tasks[id]
+	if !ok {
+		return &NotFoundError{ID: id}
+	}
+	before := t
+	if t.Closed() {
+		t.Status = StatusTodo
+	}
+	t.Complete = false
+	t.CompleteDate = sql.NullString{}
+	if statusChanged(before, t) {
+		t.UpdatedAt = timestamp()
+	}
+	m.tasks[id] = t
+	return nil
+}
+
+// UpdateMany saves the title, due date, priority, tags and status of each task, changing nothing if any task is missing
+func (m *MemoryStore) UpdateMany(tasks []Task) error {
+	for _, t := range tasks {
+		if _, ok := m.tasks[t.ID]; !ok {
+			return &NotFoundError{ID: t.ID}
+		}
+	}
+	for _, t := range tasks {
+		_ = m.Update(t)
+		_ = m.SetStatus(t.ID, t.Status)
+	}
+	return nil
+}
+
+// SetStatus moves the task to a new status, keeping the complete flag and date in sync
+func (m *MemoryStore) SetStatus(id int, status string) error {
+	t, ok := m.tasks[id]
+	if !ok {
+		return &NotFoundError{ID: id}
+	}
+	before := t
+	t.Status = status
+	t.Complete = status == StatusDone
+	if !t.Complete {
+		t.CompleteDate = sql.NullString{}
+	} else if !t.CompleteDate.Valid {
+		t.CompleteDate = sql.NullString{String: now().Format("2006-01-02"), Valid: true}
+	}
+	if statusChanged(before, t) {
+		t.UpdatedAt = timestamp()
+	}
+	m.tasks[id] = t
+	return nil
+}
+
+// statusChanged reports whether the status, complete flag or completion date differ between before and after
+func statusChanged(before, after Task) bool {
+	return before.Status != after.Status || before.Complete != after.Complete || before.CompleteDate != after.CompleteDate
+}
+
+// Search returns tasks matching the query, those matching the most wanted terms first, then in display order
+func (m *MemoryStore) Search(q Query) ([]Task, error) {
+
+	// regular expressions match in display order, without ranking
+	if q.Regexp {
+		re, err := CompileRegexp(q.Keyword)
+		if err != nil {
+			return nil, err
+		}
+		var tasks []Task
+		for _, t := range m.tasks {
+			if q.Filter.Match(t) && matchRegexp(q, re, t) {
+				tasks = append(tasks, t)
+			}
+		}
+		sortTasks(tasks)
+		return tasks, nil
+	}
+
+	expr, err := ParseSearch(q.Keyword)
+	if err != nil {
+		return nil, err
+	}
+
+	// find matching tasks, and count how many wanted terms each contains
+	var tasks []Task
+	hits := make(map[int]int)
+	for _, t := range m.tasks {
+		if !q.Filter.Match(t) || !matchQuery(q, expr, t) {
+			continue
+		}
+		tasks = append(tasks, t)
+		for _, n := range expr.positiveTerms() {
+			if n.matchText(t.Title) {
+				hits[t.ID]++
+			}
+		}
+	}
+
+	sortTasks(tasks)
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return hits[tasks[i].ID] > hits[tasks[j].ID]
+	})
+	return tasks, nil
+}
+
+// Renumber compacts task IDs into 1..N, as described by Renumbering
+func (m *MemoryStore) Renumber(all bool) (map[int]int, error) {
+	tasks := make([]Task, 0, len(m.tasks))
+	for _, t := range m.tasks {
+		tasks = append(tasks, t)
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+
+	changed := Renumbering(tasks, all)
+	renumbered := make(map[int]Task, len(m.tasks))
+	for _, t := range tasks {
+		if id, ok := changed[t.ID]; ok {
+			t.ID = id
+		}
+		renumbered[t.ID] = t
+	}
+	m.tasks = renumbered
+	m.nextID = len(tasks) + 1
+	return changed, nil
+}
+
+// Backup takes a snapshot of the current tasks
+func (m *MemoryStore) Backup() error {
+	m.backup = copyTasks(m.tasks)
+	m.backupNext = m.nextID
+	return nil
+}
+
+// Restore replaces the current tasks with the snapshot taken by Backup, and discards the snapshot
+func (m *MemoryStore) Restore() error {
+	if m.backup == nil {
+		return errors.New("no backup")
+	}
+	m.tasks, m.nextID = m.backup, m.backupNext
+	m.backup = nil
+	return nil
+}
+
+// Reset deletes all tasks and the backup, restarting IDs at 1
+func (m *MemoryStore) Reset() error {
+	m.tasks = make(map[int]Task)
+	m.nextID = 1
+	m.backup = nil
+	return nil
+}
+
+// load replaces the tasks with tasks, keeping their IDs, and continues numbering after the highest ID
+func (m *MemoryStore) load(tasks []Task) {
+	m.tasks = make(map[int]Task, len(tasks))
+	m.nextID = 1
+	for _, t := range tasks {
+		m.tasks[t.ID] = t
+		m.nextID = max(m.nextID, t.ID+1)
+	}
+}
+
+// copyTasks returns a shallow copy of a task map
+func copyTasks(tasks map[int]Task) map[int]Task {
+	c := make(map[int]Task, len(tasks))
+	for id, t := range tasks {
+		c[id] = t
+	}
+	return c
+}
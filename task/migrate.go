@@ -0,0 +1,160 @@
+package task
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// migrations upgrade the database schema one version at a time.
+// migrations[i] upgrades a database from version i to version i+1, the version is stored in PRAGMA user_version.
+// new migrations must only ever be appended.
+var migrations = []func(tx *sql.Tx) error{
+	createTasksTable,
+	addTimestamps,
+	addStatus,
+	addTags,
+	addUUIDs,
+}
+
+// migrate brings the database schema up to date, applying each pending migration in its own transaction
+func migrate(db *sql.DB) error {
+
+	// get the current schema version
+	var version int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	// apply each migration newer than the current version
+	for v := version; v < len(migrations); v++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+
+		if err := migrations[v](tx); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to migrate schema to version %d: %w", v+1, err)
+		}
+
+		// PRAGMA does not accept placeholders, v is always an integer
+		if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", v+1)); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to record schema version: %w", err)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createTasksTable defines the tasks table according to the Task struct.
+// databases created before migrations were introduced already have this table
+func createTasksTable(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS tasks (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		title TEXT NOT NULL,
+		due TEXT,
+		complete BOOLEAN NOT NULL DEFAULT false,
+		priority BOOLEAN NOT NULL DEFAULT false,
+		complete_date TEXT
+	);`)
+	return err
+}
+
+// addTimestamps adds the created_at and updated_at columns.
+// the real creation time of existing tasks is unknown, so they are stamped with the time of the upgrade
+func addTimestamps(tx *sql.Tx) error {
+	stamp := formatTimestamp(time.Now())
+
+	for _, stmt := range []string{
+		"ALTER TABLE tasks ADD COLUMN created_at TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE tasks ADD COLUMN updated_at TEXT NOT NULL DEFAULT ''",
+	} {
+		if _, err := tx.Exec(stmt); err != nil {
+			return err
+		}
+	}
+
+	_, err := tx.Exec("UPDATE tasks SET created_at = ?, updated_at = ?", stamp, stamp)
+	return err
+}
+
+// addStatus adds the status column, mapping complete tasks to done and all others to todo
+func addStatus(tx *sql.Tx) error {
+	if _, err := tx.Exec("ALTER TABLE tasks ADD COLUMN status TEXT NOT NULL DEFAULT '" + StatusTodo + "'"); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec("UPDATE tasks SET status = ? WHERE complete = 1", StatusDone)
+	return err
+}
+
+// addTags creates the task_tags table, which holds each tag of each task
+func addTags(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE task_tags (
+		task_id INTEGER NOT NULL REFERENCES tasks(id),
+		tag TEXT NOT NULL,
+		PRIMARY KEY (task_id, tag)
+	);`)
+	return err
+}
+
+// addUUIDs adds the uuid and handle columns, giving each existing task a new UUID and handle
+func addUUIDs(tx *sql.Tx) error {
+	for _, stmt := range []string{
+		"ALTER TABLE tasks ADD COLUMN uuid TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE tasks ADD COLUMN handle TEXT NOT NULL DEFAULT ''",
+	} {
+		if _, err := tx.Exec(stmt); err != nil {
+			return err
+		}
+	}
+
+	// get existing task IDs, reading them all before updating
+	rows, err := tx.Query("SELECT id FROM tasks ORDER BY id")
+	if err != nil {
+		return err
+	}
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	// assign identities in ID order, so older tasks get the shorter handles
+	for _, id := range ids {
+		uuid, handle, err := newIdentity(handleTaken(tx))
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec("UPDATE tasks SET uuid = ?, handle = ? WHERE id = ?", uuid, handle, id); err != nil {
+			return err
+		}
+	}
+
+	// existing rows are filled in, so the columns can now be unique
+	for _, stmt := range []string{
+		"CREATE UNIQUE INDEX tasks_uuid ON tasks(uuid)",
+		"CREATE UNIQUE INDEX tasks_handle ON tasks(handle)",
+	} {
+		if _, err := tx.Exec(stmt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
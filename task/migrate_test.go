@@ -0,0 +1,102 @@
+package task
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+// createVersion creates a database at path with the schema of the given version, running stmts afterwards to
+// add tasks as that version would have stored them
+func createVersion(t *testing.T, path string, version int, stmts ...string) {
+	t.Helper()
+	db, err := sql.Open(driverName, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.Close() }()
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range migrations[:version] {
+		if err := m(tx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, stmt := range append(stmts, fmt.Sprintf("PRAGMA user_version = %d", version)) {
+		if _, err := tx.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// openVersion opens the database at path, failing the test unless it is upgraded to the latest version
+func openVersion(t *testing.T, path string) *SQLiteStore {
+	t.Helper()
+	store, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	var version int
+	if err := store.db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		t.Fatal(err)
+	}
+	if version != len(migrations) {
+		t.Errorf("schema version %d, want %d", version, len(migrations))
+	}
+	return store
+}
+
+func TestMigrateTimestamps(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.db")
+	createVersion(t, path, 1, `INSERT INTO tasks (title, due) VALUES ('old task', '2025-01-01')`)
+
+	store := openVersion(t, path)
+	got, err := store.Get(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "old task" || got.CreatedAt.IsZero() || !got.UpdatedAt.Equal(got.CreatedAt) {
+		t.Errorf("upgraded task: %+v", got)
+	}
+}
+
+func TestMigrateIsRepeatable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.db")
+	store := openVersion(t, path)
+	addTasks(t, store, Task{Title: "a"})
+	_ = store.Close()
+
+	// opening an up to date database changes nothing
+	store = openVersion(t, path)
+	if got, err := store.Get(1); err != nil || got.Title != "a" {
+		t.Errorf("task after reopening: %+v, %v", got, err)
+	}
+}
+
+func TestMigrateStatus(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.db")
+	createVersion(t, path, 2,
+		`INSERT INTO tasks (title, complete, complete_date) VALUES ('finished', 1, '2025-01-02')`,
+		`INSERT INTO tasks (title) VALUES ('unfinished')`,
+	)
+
+	store := openVersion(t, path)
+	for id, want := range map[int]string{1: StatusDone, 2: StatusTodo} {
+		got, err := store.Get(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != want || got.Complete != (want == StatusDone) {
+			t.Errorf("task %d: status %q, complete %v, want %s", id, got.Status, got.Complete, want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package task
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strconv"
+	"sync"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// driverName is the SQLite driver used by SQLiteStore, which adds the REGEXP and search_term functions to every
+// connection
+const driverName = "sqlite3_tidytask"
+
+func init() {
+	sql.Register(driverName, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			if err := conn.RegisterFunc("regexp", sqlRegexp, true); err != nil {
+				return err
+			}
+			return conn.RegisterFunc("search_term", sqlSearchTerm, true)
+		},
+	})
+}
+
+// regexpCache holds compiled patterns, so REGEXP does not recompile the pattern for every row
+var regexpCache sync.Map
+
+// sqlRegexp implements the SQL expression "s REGEXP pattern", which SQLite calls as regexp(pattern, s)
+func sqlRegexp(pattern, s string) (bool, error) {
+	re, err := CompileRegexp(pattern)
+	if err != nil {
+		return false, err
+	}
+	return re.MatchString(s), nil
+}
+
+// CompileRegexp compiles a Go RE2 pattern for a regular expression search.
+// the error describes what is wrong with the pattern and where.
+func CompileRegexp(pattern string) (*regexp.Regexp, error) {
+	if re, ok := regexpCache.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		// report the problem without the "error parsing regexp" prefix, so callers can add their own
+		var syntaxErr *syntax.Error
+		if errors.As(err, &syntaxErr) {
+			return nil, fmt.Errorf("%s: `%s`", syntaxErr.Code, syntaxErr.Expr)
+		}
+		return nil, err
+	}
+
+	regexpCache.Store(pattern, re)
+	return re, nil
+}
+
+// matchRegexp reports whether re matches any of the fields enabled in q
+func matchRegexp(q Query, re *regexp.Regexp, t Task) bool {
+	return (q.ID && re.MatchString(strconv.Itoa(t.ID))) ||
+		(q.Title && re.MatchString(t.Title)) ||
+		(q.Due && t.Due != "" && re.MatchString(t.Due))
+}
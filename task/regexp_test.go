@@ -0,0 +1,69 @@
+package task
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompileRegexp(t *testing.T) {
+	re, err := CompileRegexp(`^fix .*bug$`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := CompileRegexp(`^fix .*bug$`); again != re {
+		t.Error("pattern was compiled again instead of coming from the cache")
+	}
+
+	// errors describe the problem without Go's prefix
+	_, err = CompileRegexp(`fix (bug`)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if msg := err.Error(); strings.Contains(msg, "error parsing regexp") || !strings.Contains(msg, "missing closing )") {
+		t.Errorf("error = %q", msg)
+	}
+}
+
+func TestSearchRegexp(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			addTasks(t, store,
+				Task{Title: "fix login bug", Due: "2030-01-15"},
+				Task{Title: "Fix search bug"},
+				Task{Title: "write docs", Due: "2030-02-01", Priority: true},
+			)
+
+			tests := []struct {
+				name  string
+				query Query
+				want  []int
+			}{
+				{"anchored", Query{Keyword: `^fix .*bug$`, Title: true}, []int{1}},
+				{"ignore case", Query{Keyword: `(?i)^fix`, Title: true}, []int{1, 2}},
+				{"due", Query{Keyword: `-0[12]-`, Due: true}, []int{3, 1}},
+				{"id", Query{Keyword: `^[23]$`, ID: true}, []int{3, 2}},
+				{"several fields", Query{Keyword: `docs|^1$`, ID: true, Title: true}, []int{3, 1}},
+				{"filter", Query{Keyword: `.`, Title: true, Filter: Filter{Priority: true}}, []int{3}},
+				{"no due date", Query{Keyword: `^$`, Due: true}, nil},
+			}
+			for _, tt := range tests {
+				tt.query.Regexp = true
+				got, err := store.Search(tt.query)
+				if err != nil {
+					t.Fatalf("%s: %v", tt.name, err)
+				}
+				if len(got) == 0 && len(tt.want) == 0 {
+					continue
+				}
+				if !reflect.DeepEqual(ids(got), tt.want) {
+					t.Errorf("%s: got IDs %v, want %v", tt.name, ids(got), tt.want)
+				}
+			}
+
+			if _, err := store.Search(Query{Keyword: `[`, Regexp: true, Title: true}); err == nil {
+				t.Error("invalid pattern did not return an error")
+			}
+		})
+	}
+}
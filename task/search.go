@@ -0,0 +1,349 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SearchExpr is a parsed search query.
+//
+// Queries are made of terms, which all have to match by default. A term can be:
+//   - a word, e.g. deploy
+//   - a prefix, e.g. depl*
+//   - a phrase in double quotes, e.g. "deploy to staging"
+//
+// Terms can be combined with the upper-case operators AND, OR and NOT, and grouped with parentheses,
+// e.g. deploy AND (staging OR prod) NOT docs
+type SearchExpr struct {
+	root *searchNode
+}
+
+// searchNode is a node in a parsed search expression, either an operator or a term
+type searchNode struct {
+	op       string        // "and", "or", "not", or empty for a term
+	children []*searchNode // operands of an operator
+	text     string        // text of a term, in lower case
+	prefix   bool          // the term matches words beginning with text
+}
+
+// ParseSearch parses a search query, returning an error describing any syntax problem
+func ParseSearch(query string) (SearchExpr, error) {
+	tokens, err := tokenizeSearch(query)
+	if err != nil {
+		return SearchExpr{}, err
+	}
+	if len(tokens) == 0 {
+		return SearchExpr{}, fmt.Errorf("empty search query")
+	}
+
+	p := &searchParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return SearchExpr{}, err
+	}
+
+	// anything left over is an unmatched closing parenthesis
+	if p.pos < len(p.tokens) {
+		return SearchExpr{}, fmt.Errorf("unexpected %q in search query", p.tokens[p.pos].text)
+	}
+
+	return SearchExpr{root: root}, nil
+}
+
+// Terms returns the text of every term that has to be present for a task to match,
+// ignoring terms under NOT. it is used to highlight matches.
+func (e SearchExpr) Terms() []string {
+	var terms []string
+	for _, n := range e.positiveTerms() {
+		terms = append(terms, n.text)
+	}
+	return terms
+}
+
+// positiveTerms returns every term node that is not negated by NOT
+func (e SearchExpr) positiveTerms() []*searchNode {
+	var terms []*searchNode
+	var walk func(n *searchNode, negated bool)
+	walk = func(n *searchNode, negated bool) {
+		if n.op == "" {
+			if !negated {
+				terms = append(terms, n)
+			}
+			return
+		}
+		for _, c := range n.children {
+			walk(c, negated != (n.op == "not"))
+		}
+	}
+	if e.root != nil {
+		walk(e.root, false)
+	}
+	return terms
+}
+
+// match evaluates the expression in Go, using termMatch to decide whether each term matches
+func (e SearchExpr) match(termMatch func(n *searchNode) bool) bool {
+	var eval func(n *searchNode) bool
+	eval = func(n *searchNode) bool {
+		switch n.op {
+		case "and":
+			for _, c := range n.children {
+				if !eval(c) {
+					return false
+				}
+			}
+			return true
+		case "or":
+			for _, c := range n.children {
+				if eval(c) {
+					return true
+				}
+			}
+			return false
+		case "not":
+			return !eval(n.children[0])
+		default:
+			return termMatch(n)
+		}
+	}
+	return eval(e.root)
+}
+
+// sql renders the expression as an SQL condition, using termSQL to render each term
+func (e SearchExpr) sql(termSQL func(n *searchNode) (string, []interface{})) (string, []interface{}) {
+	var args []interface{}
+	var render func(n *searchNode) string
+	render = func(n *searchNode) string {
+		switch n.op {
+		case "and", "or":
+			parts := make([]string, len(n.children))
+			for i, c := range n.children {
+				parts[i] = render(c)
+			}
+			return "(" + strings.Join(parts, " "+strings.ToUpper(n.op)+" ") + ")"
+		case "not":
+			return "NOT " + render(n.children[0])
+		default:
+			cond, termArgs := termSQL(n)
+			args = append(args, termArgs...)
+			return cond
+		}
+	}
+	cond := render(e.root)
+	return cond, args
+}
+
+// ftsString renders a term in FTS5 query syntax, quoting it so punctuation cannot cause syntax errors
+func (n *searchNode) ftsString() string {
+	s := `"` + strings.ReplaceAll(n.text, `"`, `""`) + `"`
+	if n.prefix {
+		s += "*"
+	}
+	return s
+}
+
+// matchText reports whether the term appears in s, ignoring case.
+// words and phrases match anywhere in s, prefixes match at the start of a word
+func (n *searchNode) matchText(s string) bool {
+	s = strings.ToLower(s)
+	if !n.prefix {
+		return strings.Contains(s, n.text)
+	}
+
+	// look for the prefix at the start of each word
+	for i := 0; i < len(s); {
+		j := strings.Index(s[i:], n.text)
+		if j < 0 {
+			return false
+		}
+		start := i + j
+		if prev, _ := utf8.DecodeLastRuneInString(s[:start]); start == 0 || !isWordRune(prev) {
+			return true
+		}
+		i = start + 1
+	}
+	return false
+}
+
+// sqlSearchTerm implements the SQL function search_term(text, prefix, s), which reports whether a search term
+// matches s. it lets SQLiteStore match terms exactly as MemoryStore does, whether or not the full-text index is used
+func sqlSearchTerm(text string, prefix bool, s string) bool {
+	n := &searchNode{text: text, prefix: prefix}
+	return n.matchText(s)
+}
+
+// isWordRune reports whether r is part of a word
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// searchToken is a lexical token of a search query
+type searchToken struct {
+	kind string // "term", "phrase", "(", ")", "AND", "OR" or "NOT"
+	text string
+}
+
+// tokenizeSearch splits a search query into tokens
+func tokenizeSearch(query string) ([]searchToken, error) {
+	var tokens []searchToken
+	runes := []rune(query)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')':
+			tokens = append(tokens, searchToken{kind: string(r), text: string(r)})
+			i++
+		case r == '"':
+			// read up to the closing quote
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated phrase in search query; missing closing \"")
+			}
+			phrase := strings.TrimSpace(string(runes[i+1 : end]))
+			if phrase == "" {
+				return nil, fmt.Errorf("empty phrase in search query")
+			}
+			i = end + 1
+
+			// a phrase directly followed by * is a prefix phrase
+			if i < len(runes) && runes[i] == '*' {
+				phrase += "*"
+				i++
+			}
+			tokens = append(tokens, searchToken{kind: "phrase", text: phrase})
+		default:
+			// read a word up to the next space, parenthesis or quote
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune(`()"`, runes[end]) {
+				end++
+			}
+			word := string(runes[i:end])
+			i = end
+
+			// only upper-case operators are operators, like FTS5
+			if word == "AND" || word == "OR" || word == "NOT" {
+				tokens = append(tokens, searchToken{kind: word, text: word})
+			} else {
+				tokens = append(tokens, searchToken{kind: "term", text: word})
+			}
+		}
+	}
+
+	return tokens, nil
+}
+
+// searchParser is a recursive descent parser over search tokens
+type searchParser struct {
+	tokens []searchToken
+	pos    int
+}
+
+// peek returns the kind of the next token, or an empty string at the end of the query
+func (p *searchParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos].kind
+}
+
+// parseOr parses terms separated by OR
+func (p *searchParser) parseOr() (*searchNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &searchNode{op: "or", children: []*searchNode{left}}
+	for p.peek() == "OR" {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		node.children = append(node.children, right)
+	}
+
+	if len(node.children) == 1 {
+		return left, nil
+	}
+	return node, nil
+}
+
+// parseAnd parses terms separated by AND, or by nothing at all
+func (p *searchParser) parseAnd() (*searchNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &searchNode{op: "and", children: []*searchNode{left}}
+	for {
+		switch p.peek() {
+		case "AND":
+			p.pos++
+		case "term", "phrase", "(", "NOT":
+			// implicit AND
+		default:
+			if len(node.children) == 1 {
+				return left, nil
+			}
+			return node, nil
+		}
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		node.children = append(node.children, right)
+	}
+}
+
+// parseUnary parses a term, a parenthesised group, or NOT followed by either
+func (p *searchParser) parseUnary() (*searchNode, error) {
+	switch p.peek() {
+	case "NOT":
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &searchNode{op: "not", children: []*searchNode{operand}}, nil
+
+	case "(":
+		p.pos++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing ) in search query")
+		}
+		p.pos++
+		return node, nil
+
+	case "term", "phrase":
+		tok := p.tokens[p.pos]
+		p.pos++
+		text := strings.ToLower(tok.text)
+		prefix := strings.HasSuffix(text, "*")
+		text = strings.TrimRight(text, "*")
+		if text == "" {
+			return nil, fmt.Errorf("invalid term %q in search query", tok.text)
+		}
+		return &searchNode{text: text, prefix: prefix}, nil
+
+	case "":
+		return nil, fmt.Errorf("search query ends unexpectedly; expected a term")
+
+	default:
+		return nil, fmt.Errorf("unexpected %q in search query; expected a term", p.tokens[p.pos].text)
+	}
+}
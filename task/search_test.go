@@ -0,0 +1,152 @@
+package task
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// describe renders a parsed search expression as a string, such as (and deploy (or staging prod*))
+func describe(n *searchNode) string {
+	if n.op == "" {
+		s := n.text
+		if strings.Contains(s, " ") {
+			s = `"` + s + `"`
+		}
+		if n.prefix {
+			s += "*"
+		}
+		return s
+	}
+	parts := []string{n.op}
+	for _, c := range n.children {
+		parts = append(parts, describe(c))
+	}
+	return "(" + strings.Join(parts, " ") + ")"
+}
+
+func TestParseSearch(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"deploy", "deploy"},
+		{"Deploy", "deploy"},
+		{"deploy staging", "(and deploy staging)"},
+		{"deploy AND staging", "(and deploy staging)"},
+		{"deploy OR staging", "(or deploy staging)"},
+		{"deploy staging OR prod", "(or (and deploy staging) prod)"},
+		{"deploy (staging OR prod)", "(and deploy (or staging prod))"},
+		{"NOT docs", "(not docs)"},
+		{"deploy NOT docs", "(and deploy (not docs))"},
+		{"NOT (docs OR notes)", "(not (or docs notes))"},
+		{"depl*", "depl*"},
+		{`"deploy to staging"`, `"deploy to staging"`},
+		{`"deploy to"* prod`, `(and "deploy to"* prod)`},
+		{`"  padded phrase  "`, `"padded phrase"`},
+		{"and or not", "(and and or not)"},
+	}
+	for _, tt := range tests {
+		expr, err := ParseSearch(tt.query)
+		if err != nil {
+			t.Errorf("ParseSearch(%q) error: %v", tt.query, err)
+			continue
+		}
+		if got := describe(expr.root); got != tt.want {
+			t.Errorf("ParseSearch(%q) = %s, want %s", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestParseSearchErrors(t *testing.T) {
+	for _, query := range []string{
+		"",
+		"   ",
+		`"unterminated`,
+		`""`,
+		"deploy AND",
+		"OR deploy",
+		"NOT",
+		"(deploy",
+		"deploy)",
+		"*",
+	} {
+		if _, err := ParseSearch(query); err == nil {
+			t.Errorf("ParseSearch(%q) did not return an error", query)
+		}
+	}
+}
+
+func TestSearchTerms(t *testing.T) {
+	expr, err := ParseSearch(`deploy (staging OR "prod*") NOT docs`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := expr.Terms(), []string{"deploy", "staging", "prod"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Terms() = %v, want %v", got, want)
+	}
+}
+
+func TestMatchText(t *testing.T) {
+	tests := []struct {
+		text   string
+		prefix bool
+		s      string
+		want   bool
+	}{
+		{"stag", false, "Deploy to staging", true},
+		{"ploy", false, "Deploy to staging", true},
+		{"deploy to", false, "Deploy to staging", true},
+		{"deploy staging", false, "Deploy to staging", false},
+		{"stag", true, "Deploy to staging", true},
+		{"ploy", true, "Deploy to staging", false},
+		{"prod", true, "fix (prod) bug", true},
+		{"prod", true, "reproduce", false},
+		{"café", true, "Visit Café Rouge", true},
+	}
+	for _, tt := range tests {
+		n := &searchNode{text: tt.text, prefix: tt.prefix}
+		if got := n.matchText(tt.s); got != tt.want {
+			t.Errorf("matchText(%q, prefix %v) in %q = %v, want %v", tt.text, tt.prefix, tt.s, got, tt.want)
+		}
+	}
+}
+
+// TestSearchWithoutFTS checks the SQLite search without the full-text index, as in builds without sqlite_fts5,
+// matches terms as MemoryStore does
+func TestSearchWithoutFTS(t *testing.T) {
+	sqlite, err := Open(filepath.Join(t.TempDir(), "tasks.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = sqlite.Close() }()
+	sqlite.fts = false
+
+	memory := NewMemoryStore()
+	for _, store := range []Store{sqlite, memory} {
+		addTasks(t, store,
+			Task{Title: "Deploy to staging", Due: "2030-05-01"},
+			Task{Title: "Reproduce 100% CPU bug"},
+			Task{Title: "Rename file_name field"},
+		)
+	}
+
+	for _, keyword := range []string{"stag", "duce", "repro*", "100%", "file_", "_name", "2030", "deploy NOT prod"} {
+		q := Query{Keyword: keyword, ID: true, Title: true, Due: true}
+		got, err := sqlite.Search(q)
+		if err != nil {
+			t.Fatalf("%q: %v", keyword, err)
+		}
+		want, err := memory.Search(q)
+		if err != nil {
+			t.Fatalf("%q: %v", keyword, err)
+		}
+		if !reflect.DeepEqual(ids(got), ids(want)) {
+			t.Errorf("%q: SQLite found %v, memory found %v", keyword, ids(got), ids(want))
+		}
+		if len(got) == 0 {
+			t.Errorf("%q: found nothing", keyword)
+		}
+	}
+}
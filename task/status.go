@@ -0,0 +1,24 @@
+package task
+
+// Built-in task statuses. Tasks are created as todo, and complete tasks are done.
+const (
+	StatusTodo      = "todo"
+	StatusDoing     = "doing"
+	StatusBlocked   = "blocked"
+	StatusDone      = "done"
+	StatusCancelled = "cancelled"
+)
+
+// BuiltinStatuses lists the built-in statuses in workflow order
+var BuiltinStatuses = []string{StatusTodo, StatusDoing, StatusBlocked, StatusDone, StatusCancelled}
+
+// IsClosedStatus reports whether a status means no more work is expected on the task.
+// Only done and cancelled are closed, user-defined statuses are always open.
+func IsClosedStatus(status string) bool {
+	return status == StatusDone || status == StatusCancelled
+}
+
+// Closed reports whether the task is done or cancelled
+func (t Task) Closed() bool {
+	return IsClosedStatus(t.Status)
+}
@@ -0,0 +1,290 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Store is the interface to persistent task storage.
+// SQLiteStore is the implementation used by TidyTask, MemoryStore keeps tasks in memory for tests.
+type Store interface {
+	// Add inserts a new task and returns its assigned ID
+	Add(t Task) (int, error)
+
+	// Get returns the task with the given ID, or an error matching ErrNotFound if it does not exist
+	Get(id int) (Task, error)
+
+	// List returns all tasks that match the filter, in display order
+	List(f Filter) ([]Task, error)
+
+	// Update saves the title, due date, priority and tags of an existing task
+	Update(t Task) error
+
+	// UpdateMany saves the title, due date, priority, tags and status of each task in one transaction.
+	// if any task cannot be saved, none of them are changed
+	UpdateMany(tasks []Task) error
+
+	// Delete removes the task with the given ID
+	Delete(id int) error
+
+	// Complete marks the task with the given ID as complete, recording today as the completion date
+	Complete(id int) error
+
+	// Reopen marks the task with the given ID as open and clears its completion date
+	Reopen(id int) error
+
+	// SetStatus moves the task with the given ID to a new status, keeping the complete flag and date in sync
+	SetStatus(id int, status string) error
+
+	// Renumber compacts task IDs into the sequence 1..N in one transaction, returning the new ID of each task whose
+	// ID changed. open tasks are numbered first, unless all is true, when every task keeps its order
+	Renumber(all bool) (map[int]int, error)
+
+	// Search returns tasks matching the query, in display order
+	Search(q Query) ([]Task, error)
+
+	// Backup saves a copy of the current tasks that can be restored with Restore
+	Backup() error
+
+	// Restore replaces the current tasks with the most recent backup, and discards the backup
+	Restore() error
+
+	// Reset deletes all tasks and the backup, and closes the store
+	Reset() error
+
+	// Path describes where the tasks are stored
+	Path() string
+
+	// NextID returns the ID the next added task will be given. IDs of removed tasks are not reused, so it can be
+	// higher than one more than the highest ID
+	NextID() (int, error)
+
+	// Close releases any resources held by the store, it is safe to call more than once
+	Close() error
+}
+
+// ErrNotFound is matched by errors returned when a task does not exist
+var ErrNotFound = errors.New("task does not exist")
+
+// NotFoundError reports that no task exists with the given ID
+type NotFoundError struct {
+	ID int
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("task with ID %d does not exist", e.ID)
+}
+
+// Is allows errors.Is(err, ErrNotFound) to match a NotFoundError
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// Renumbering returns the new ID of each task whose ID changes when tasks, in ID order, are compacted into 1..N.
+// open tasks come first, then closed tasks, unless all is true, when every task keeps its order
+func Renumbering(tasks []Task, all bool) map[int]int {
+	ordered := make([]Task, 0, len(tasks))
+	if all {
+		ordered = append(ordered, tasks...)
+	} else {
+		for _, t := range tasks {
+			if !t.Closed() {
+				ordered = append(ordered, t)
+			}
+		}
+		for _, t := range tasks {
+			if t.Closed() {
+				ordered = append(ordered, t)
+			}
+		}
+	}
+
+	changed := make(map[int]int)
+	for i, t := range ordered {
+		if t.ID != i+1 {
+			changed[t.ID] = i + 1
+		}
+	}
+	return changed
+}
+
+// Filter restricts which tasks are returned by List and Search.
+// Only tasks that satisfy all enabled constraints match, the zero value matches every task.
+type Filter struct {
+	Complete bool   // only complete (done) tasks
+	Open     bool   // only open tasks, that are neither done nor cancelled
+	Closed   bool   // only closed tasks, that are done or cancelled
+	Priority bool   // only high priority tasks
+	Normal   bool   // only normal priority tasks
+	Status   string // only tasks with this status, ignored when empty
+
+	UpdatedBefore time.Time // only tasks last changed before this time, ignored when zero
+
+	Tag string // only tasks with this tag, ignored when empty
+
+	// only tasks due within these dates, inclusive, in YYYY-MM-DD format.
+	// each is ignored when empty, and tasks with no due date are skipped when either is set
+	DueFrom string
+	DueTo   string
+}
+
+// Match reports whether the task satisfies every enabled constraint of the filter
+func (f Filter) Match(t Task) bool {
+
+	// skip task if Priority is active and task is not priority
+	if f.Priority && !t.Priority {
+		return false
+	}
+
+	// skip task if Complete is active and task is not complete
+	if f.Complete && !t.Complete {
+		return false
+	}
+
+	// skip the task if Open is active and task is done or cancelled
+	if f.Open && t.Closed() {
+		return false
+	}
+
+	// skip the task if Closed is active and task is still open
+	if f.Closed && !t.Closed() {
+		return false
+	}
+
+	// skip the task if Status is set and does not match
+	if f.Status != "" && t.Status != f.Status {
+		return false
+	}
+
+	// skip task if Normal is active and task is priority
+	if f.Normal && t.Priority {
+		return false
+	}
+
+	// skip task if it has been changed since UpdatedBefore, compared at the precision timestamps are stored
+	if !f.UpdatedBefore.IsZero() && !t.UpdatedAt.Before(f.UpdatedBefore.Truncate(time.Second)) {
+		return false
+	}
+
+	// skip task if Tag is set and the task does not have it
+	if f.Tag != "" && !t.HasTag(f.Tag) {
+		return false
+	}
+
+	// skip task if it is not due within DueFrom and DueTo
+	if f.DueFrom != "" || f.DueTo != "" {
+		if t.Due == "" || (f.DueFrom != "" && t.Due < f.DueFrom) || (f.DueTo != "" && t.Due > f.DueTo) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Query describes a search over task fields
+type Query struct {
+	Keyword string // search query, in the syntax described by SearchExpr, or a regular expression
+	Regexp  bool   // keyword is a Go RE2 regular expression, matched against each enabled field
+	ID      bool   // match keyword against the task ID
+	Title   bool   // match keyword against the title
+	Due     bool   // match keyword against the due date
+	Filter  Filter // constraints applied to the matching tasks
+}
+
+// matchQuery reports whether the task matches the parsed query in any of the fields enabled in the query.
+// terms are matched case-insensitively, like SQLite's LIKE operator.
+func matchQuery(q Query, expr SearchExpr, t Task) bool {
+	return expr.match(func(n *searchNode) bool {
+		return (q.ID && n.matchText(strconv.Itoa(t.ID))) ||
+			(q.Title && n.matchText(t.Title)) ||
+			(q.Due && n.matchText(t.Due))
+	})
+}
+
+// Orders accepted by SortTasks
+const (
+	SortDefault = "default" // completion status, priority, then due date
+	SortDue     = "due"     // due date, earliest first, tasks without a due date last
+	SortCreated = "created" // creation time, oldest first
+	SortUpdated = "updated" // time last changed, least recently changed first
+)
+
+// SortOrders lists the orders accepted by SortTasks
+var SortOrders = []string{SortDefault, SortDue, SortCreated, SortUpdated}
+
+// SortTasks orders tasks in place by one of the Sort orders, using the ID to break ties.
+// it returns an error if the order is not recognised
+func SortTasks(tasks []Task, order string) error {
+	var less func(a, b Task) bool
+
+	switch order {
+	case SortDefault, "":
+		sortTasks(tasks)
+		return nil
+	case SortDue:
+		less = func(a, b Task) bool {
+			if (a.Due == "") != (b.Due == "") {
+				return a.Due != ""
+			}
+			return a.Due < b.Due
+		}
+	case SortCreated:
+		less = func(a, b Task) bool { return a.CreatedAt.Before(b.CreatedAt) }
+	case SortUpdated:
+		less = func(a, b Task) bool { return a.UpdatedAt.Before(b.UpdatedAt) }
+	default:
+		return fmt.Errorf("unknown sort order %q; use one of %s", order, strings.Join(SortOrders, ", "))
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		a, b := tasks[i], tasks[j]
+		if less(a, b) {
+			return true
+		}
+		if less(b, a) {
+			return false
+		}
+		return a.ID < b.ID
+	})
+	return nil
+}
+
+// sortTasks orders tasks for display by completion status, priority, presence of a due date,
+// and due date ascending, using the ID to break ties.
+func sortTasks(tasks []Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		a, b := tasks[i], tasks[j]
+
+		// open tasks first
+		if a.Closed() != b.Closed() {
+			return !a.Closed()
+		}
+
+		// priority tasks first
+		if a.Priority != b.Priority {
+			return a.Priority
+		}
+
+		// tasks with a due date before tasks without
+		if (a.Due == "") != (b.Due == "") {
+			return a.Due != ""
+		}
+
+		// earliest due date first
+		if a.Due != b.Due {
+			return a.Due < b.Due
+		}
+
+		return a.ID < b.ID
+	})
+}
+
+// check both implementations satisfy Store at compile time
+var (
+	_ Store = (*SQLiteStore)(nil)
+	_ Store = (*MemoryStore)(nil)
+)
@@ -0,0 +1,366 @@
+package task
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// stores returns a fresh instance of each Store implementation, so the same behaviour can be checked against both
+func stores(t *testing.T) map[string]Store {
+	t.Helper()
+	sqlite, err := Open(filepath.Join(t.TempDir(), "tasks.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = sqlite.Close() })
+	return map[string]Store{"memory": NewMemoryStore(), "sqlite": sqlite}
+}
+
+// addTasks adds each task to store, failing the test on error
+func addTasks(t *testing.T, store Store, tasks ...Task) {
+	t.Helper()
+	for _, tk := range tasks {
+		if _, err := store.Add(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// ids returns the IDs of tasks, in order
+func ids(tasks []Task) []int {
+	out := make([]int, len(tasks))
+	for i, t := range tasks {
+		out[i] = t.ID
+	}
+	return out
+}
+
+func TestStoreAddGet(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			id, err := store.Add(Task{Title: "Write report", Due: "2030-01-02", Priority: true, Tags: []string{"work", "b", "work"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id != 1 {
+				t.Errorf("first task has ID %d, want 1", id)
+			}
+
+			got, err := store.Get(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Title != "Write report" || got.Due != "2030-01-02" || !got.Priority {
+				t.Errorf("got %+v", got)
+			}
+			if got.Status != StatusTodo || got.Complete || got.CompleteDate.Valid {
+				t.Errorf("new task is not open: %+v", got)
+			}
+			if !reflect.DeepEqual(got.Tags, []string{"b", "work"}) {
+				t.Errorf("tags = %v, want [b work]", got.Tags)
+			}
+			if got.UUID == "" || got.Handle == "" {
+				t.Errorf("task has no UUID or handle: %+v", got)
+			}
+			if got.CreatedAt.IsZero() || !got.UpdatedAt.Equal(got.CreatedAt) {
+				t.Errorf("timestamps not set: created %v, updated %v", got.CreatedAt, got.UpdatedAt)
+			}
+
+			if _, err := store.Get(99); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Get(99) error = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func TestStoreList(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			addTasks(t, store,
+				Task{Title: "a", Due: "2030-01-05"},
+				Task{Title: "b", Priority: true, Tags: []string{"work"}},
+				Task{Title: "c", Due: "2030-01-01"},
+				Task{Title: "d", Due: "2030-02-01"},
+			)
+			if err := store.Complete(4); err != nil {
+				t.Fatal(err)
+			}
+
+			tests := []struct {
+				name   string
+				filter Filter
+				want   []int
+			}{
+				{"all", Filter{}, []int{2, 3, 1, 4}},
+				{"open", Filter{Open: true}, []int{2, 3, 1}},
+				{"complete", Filter{Complete: true}, []int{4}},
+				{"priority", Filter{Priority: true}, []int{2}},
+				{"normal", Filter{Normal: true}, []int{3, 1, 4}},
+				{"closed", Filter{Closed: true}, []int{4}},
+				{"tag", Filter{Tag: "work"}, []int{2}},
+				{"due range", Filter{DueFrom: "2030-01-01", DueTo: "2030-01-31"}, []int{3, 1}},
+				{"status", Filter{Status: StatusDone}, []int{4}},
+			}
+			for _, tt := range tests {
+				got, err := store.List(tt.filter)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(ids(got), tt.want) {
+					t.Errorf("%s: got IDs %v, want %v", tt.name, ids(got), tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestStoreUpdateDelete(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			addTasks(t, store, Task{Title: "old", Tags: []string{"x"}})
+
+			err := store.Update(Task{ID: 1, Title: "new", Due: "2030-03-04", Priority: true, Tags: []string{"y"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, _ := store.Get(1)
+			if got.Title != "new" || got.Due != "2030-03-04" || !got.Priority || !reflect.DeepEqual(got.Tags, []string{"y"}) {
+				t.Errorf("update not saved: %+v", got)
+			}
+
+			if err := store.Update(Task{ID: 2, Title: "missing"}); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Update of missing task error = %v, want ErrNotFound", err)
+			}
+			if err := store.Delete(2); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Delete of missing task error = %v, want ErrNotFound", err)
+			}
+
+			if err := store.Delete(1); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := store.Get(1); !errors.Is(err, ErrNotFound) {
+				t.Errorf("deleted task still exists: %v", err)
+			}
+		})
+	}
+}
+
+func TestStoreStatus(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			addTasks(t, store, Task{Title: "a"}, Task{Title: "b"})
+
+			if err := store.Complete(1); err != nil {
+				t.Fatal(err)
+			}
+			got, _ := store.Get(1)
+			if got.Status != StatusDone || !got.Complete || !got.CompleteDate.Valid {
+				t.Errorf("completed task: %+v", got)
+			}
+
+			if err := store.Reopen(1); err != nil {
+				t.Fatal(err)
+			}
+			got, _ = store.Get(1)
+			if got.Status != StatusTodo || got.Complete || got.CompleteDate.Valid {
+				t.Errorf("reopened task: %+v", got)
+			}
+
+			// reopening an open task keeps its status
+			if err := store.SetStatus(2, StatusDoing); err != nil {
+				t.Fatal(err)
+			}
+			if err := store.Reopen(2); err != nil {
+				t.Fatal(err)
+			}
+			if got, _ := store.Get(2); got.Status != StatusDoing {
+				t.Errorf("reopened task in progress has status %q, want doing", got.Status)
+			}
+
+			if err := store.SetStatus(3, StatusDone); !errors.Is(err, ErrNotFound) {
+				t.Errorf("SetStatus of missing task error = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func TestStoreUpdateMany(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			addTasks(t, store, Task{Title: "a"}, Task{Title: "b"})
+
+			// a missing task changes nothing
+			err := store.UpdateMany([]Task{{ID: 1, Title: "changed", Status: StatusTodo}, {ID: 3, Title: "missing"}})
+			if !errors.Is(err, ErrNotFound) {
+				t.Errorf("error = %v, want ErrNotFound", err)
+			}
+			if got, _ := store.Get(1); got.Title != "a" {
+				t.Errorf("task 1 changed to %q", got.Title)
+			}
+
+			err = store.UpdateMany([]Task{{ID: 1, Title: "a2", Status: StatusDone}, {ID: 2, Title: "b2", Status: StatusBlocked}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, _ := store.Get(1); got.Title != "a2" || !got.Complete {
+				t.Errorf("task 1: %+v", got)
+			}
+			if got, _ := store.Get(2); got.Title != "b2" || got.Status != StatusBlocked {
+				t.Errorf("task 2: %+v", got)
+			}
+		})
+	}
+}
+
+func TestStoreSearch(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			addTasks(t, store,
+				Task{Title: "Deploy to staging", Due: "2030-05-01"},
+				Task{Title: "Write release notes"},
+				Task{Title: "Deploy to production"},
+			)
+
+			tests := []struct {
+				name  string
+				query Query
+				want  []int
+			}{
+				{"substring", Query{Keyword: "stag", Title: true}, []int{1}},
+				{"case", Query{Keyword: "DEPLOY", Title: true}, []int{1, 3}},
+				{"and", Query{Keyword: "deploy production", Title: true}, []int{3}},
+				{"or", Query{Keyword: "notes OR production", Title: true}, []int{2, 3}},
+				{"not", Query{Keyword: "deploy NOT staging", Title: true}, []int{3}},
+				{"phrase", Query{Keyword: `"release notes"`, Title: true}, []int{2}},
+				{"prefix", Query{Keyword: "prod*", Title: true}, []int{3}},
+				{"due", Query{Keyword: "2030-05", Due: true}, []int{1}},
+				{"id", Query{Keyword: "2", ID: true}, []int{2}},
+				{"no fields", Query{Keyword: "deploy"}, nil},
+				{"regexp", Query{Keyword: "^Deploy.*ing$", Regexp: true, Title: true}, []int{1}},
+			}
+			for _, tt := range tests {
+				got, err := store.Search(tt.query)
+				if err != nil {
+					t.Fatalf("%s: %v", tt.name, err)
+				}
+				if len(got) == 0 && len(tt.want) == 0 {
+					continue
+				}
+				if !reflect.DeepEqual(ids(got), tt.want) {
+					t.Errorf("%s: got IDs %v, want %v", tt.name, ids(got), tt.want)
+				}
+			}
+
+			if _, err := store.Search(Query{Keyword: `"unterminated`, Title: true}); err == nil {
+				t.Error("invalid query did not return an error")
+			}
+		})
+	}
+}
+
+func TestStoreRenumber(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			addTasks(t, store, Task{Title: "a"}, Task{Title: "b"}, Task{Title: "c"}, Task{Title: "d"})
+			if err := store.Delete(1); err != nil {
+				t.Fatal(err)
+			}
+			if err := store.Complete(2); err != nil {
+				t.Fatal(err)
+			}
+
+			changed, err := store.Renumber(false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := map[int]int{3: 1, 4: 2, 2: 3}; !reflect.DeepEqual(changed, want) {
+				t.Errorf("changed = %v, want %v", changed, want)
+			}
+			for id, title := range map[int]string{1: "c", 2: "d", 3: "b"} {
+				if got, _ := store.Get(id); got.Title != title {
+					t.Errorf("task %d is %q, want %q", id, got.Title, title)
+				}
+			}
+
+			// new tasks are numbered after the renumbered tasks
+			id, err := store.Add(Task{Title: "e"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id != 4 {
+				t.Errorf("new task has ID %d, want 4", id)
+			}
+		})
+	}
+}
+
+func TestStoreRenumberAll(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			addTasks(t, store, Task{Title: "a"}, Task{Title: "b"}, Task{Title: "c"})
+			if err := store.Complete(1); err != nil {
+				t.Fatal(err)
+			}
+
+			// IDs already in sequence are left alone
+			changed, err := store.Renumber(true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(changed) != 0 {
+				t.Errorf("changed = %v, want nothing", changed)
+			}
+
+			// with all, closed tasks keep their place
+			if err := store.Delete(2); err != nil {
+				t.Fatal(err)
+			}
+			changed, err = store.Renumber(true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := map[int]int{3: 2}; !reflect.DeepEqual(changed, want) {
+				t.Errorf("changed = %v, want %v", changed, want)
+			}
+			for id, title := range map[int]string{1: "a", 2: "c"} {
+				if got, _ := store.Get(id); got.Title != title {
+					t.Errorf("task %d is %q, want %q", id, got.Title, title)
+				}
+			}
+		})
+	}
+}
+
+func TestStoreBackupRestoreReset(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			addTasks(t, store, Task{Title: "a"})
+			if err := store.Backup(); err != nil {
+				t.Fatal(err)
+			}
+			addTasks(t, store, Task{Title: "b"})
+
+			if err := store.Restore(); err != nil {
+				t.Fatal(err)
+			}
+			got, err := store.List(Filter{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(ids(got), []int{1}) {
+				t.Errorf("after restore got IDs %v, want [1]", ids(got))
+			}
+
+			// the backup is discarded once restored
+			if err := store.Restore(); err == nil {
+				t.Error("second restore did not return an error")
+			}
+
+			if err := store.Reset(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package task
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// CheckTag returns an error if tag cannot be used as a tag name. tags are single words, such as sprint-5
+func CheckTag(tag string) error {
+	if tag == "" || strings.ContainsFunc(tag, func(r rune) bool { return unicode.IsSpace(r) || r == ',' }) {
+		return fmt.Errorf("invalid tag %q; tags must be a single word without commas", tag)
+	}
+	return nil
+}
+
+// NormaliseTags returns a sorted copy of tags without duplicates
+func NormaliseTags(tags []string) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+	tags = slices.Clone(tags)
+	slices.Sort(tags)
+	return slices.Compact(tags)
+}
+
+// HasTag reports whether the task has the tag
+func (t Task) HasTag(tag string) bool {
+	return slices.Contains(t.Tags, tag)
+}
@@ -1,13 +1,48 @@
 package task
 
-import "database/sql"
+import (
+	"database/sql"
+	"time"
+)
+
+// timestampLayout is the layout of the created_at and updated_at columns, always stored in UTC.
+// it sorts lexicographically in time order, so timestamps can be compared as text in SQL
+const timestampLayout = "2006-01-02 15:04:05"
+
+// now returns the current time, it is a variable so the clock can be controlled in tests
+var now = time.Now
 
 // Task represents a to-do list task
 type Task struct {
 	ID           int            `json:"id"`            // Unique ID for task (primary key)
+	UUID         string         `json:"uuid"`          // Globally unique ID, kept when tasks are moved between databases
+	Handle       string         `json:"handle"`        // Short, stable prefix of the encoded UUID, such as k3fa
 	Title        string         `json:"title"`         // Title or description of the task (mandatory)
 	Due          string         `json:"due"`           // Due date as string (empty string represents no due set)
-	Complete     bool           `json:"complete"`      // Flag indicating the tasks completion status
+	Status       string         `json:"status"`        // Workflow status, such as todo, doing or done
+	Complete     bool           `json:"complete"`      // Flag indicating the tasks completion status (status is done)
 	CompleteDate sql.NullString `json:"complete_date"` // Nullable date string representing when task was completed
 	Priority     bool           `json:"priority"`      // Flag indicating if the task is marked as high priority
+	Tags         []string       `json:"tags"`          // Tags used to group tasks, such as sprint-5, in sorted order
+	CreatedAt    time.Time      `json:"created_at"`    // Time the task was added
+	UpdatedAt    time.Time      `json:"updated_at"`    // Time the task was last changed
+}
+
+// timestamp returns the current time at the precision stored in the database
+func timestamp() time.Time {
+	return now().UTC().Truncate(time.Second)
+}
+
+// formatTimestamp formats t for storage in the created_at and updated_at columns
+func formatTimestamp(t time.Time) string {
+	return t.UTC().Format(timestampLayout)
+}
+
+// parseTimestamp parses a stored timestamp, returning the zero time if it is empty or invalid
+func parseTimestamp(s string) time.Time {
+	t, err := time.Parse(timestampLayout, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
 }
@@ -0,0 +1,130 @@
+package task
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// setNow makes the store clock return t until the test ends
+func setNow(tb testing.TB, t time.Time) {
+	tb.Helper()
+	old := now
+	now = func() time.Time { return t }
+	tb.Cleanup(func() { now = old })
+}
+
+func TestTimestamps(t *testing.T) {
+	created := time.Date(2025, 3, 1, 9, 30, 15, 500, time.UTC)
+	updated := created.Add(26 * time.Hour)
+	completed := updated.Add(time.Hour)
+
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			setNow(t, created)
+			addTasks(t, store, Task{Title: "a"}, Task{Title: "b"})
+
+			got, _ := store.Get(1)
+			if want := created.Truncate(time.Second); !got.CreatedAt.Equal(want) || !got.UpdatedAt.Equal(want) {
+				t.Errorf("new task created %v, updated %v, want %v", got.CreatedAt, got.UpdatedAt, want)
+			}
+
+			// changes move the updated time, never the created time
+			setNow(t, updated)
+			if err := store.Update(Task{ID: 1, Title: "a2"}); err != nil {
+				t.Fatal(err)
+			}
+			setNow(t, completed)
+			if err := store.Complete(2); err != nil {
+				t.Fatal(err)
+			}
+
+			for id, want := range map[int]time.Time{1: updated, 2: completed} {
+				got, _ := store.Get(id)
+				if !got.UpdatedAt.Equal(want.Truncate(time.Second)) {
+					t.Errorf("task %d updated %v, want %v", id, got.UpdatedAt, want)
+				}
+				if !got.CreatedAt.Equal(created.Truncate(time.Second)) {
+					t.Errorf("task %d created %v, want %v", id, got.CreatedAt, created)
+				}
+			}
+
+			// only task 1 was last changed before the completion
+			tasks, err := store.List(Filter{UpdatedBefore: completed})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(ids(tasks), []int{1}) {
+				t.Errorf("UpdatedBefore got IDs %v, want [1]", ids(tasks))
+			}
+
+			// setting a status the task already has is not a change
+			setNow(t, completed.Add(time.Hour))
+			for _, err := range []error{store.Complete(2), store.Reopen(1), store.SetStatus(1, StatusTodo)} {
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			for id, want := range map[int]time.Time{1: updated, 2: completed} {
+				if got, _ := store.Get(id); !got.UpdatedAt.Equal(want.Truncate(time.Second)) {
+					t.Errorf("task %d updated %v without a change, want %v", id, got.UpdatedAt, want)
+				}
+			}
+			if err := store.Reopen(2); err != nil {
+				t.Fatal(err)
+			}
+			if got, _ := store.Get(2); !got.UpdatedAt.Equal(completed.Add(time.Hour).Truncate(time.Second)) {
+				t.Errorf("reopened task updated %v, want %v", got.UpdatedAt, completed.Add(time.Hour))
+			}
+		})
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	stamp := time.Date(2025, 3, 1, 9, 30, 15, 0, time.FixedZone("CET", 3600))
+	if got := formatTimestamp(stamp); got != "2025-03-01 08:30:15" {
+		t.Errorf("formatTimestamp() = %q, stored times must be UTC", got)
+	}
+	if got := parseTimestamp(formatTimestamp(stamp)); !got.Equal(stamp) {
+		t.Errorf("parseTimestamp() = %v, want %v", got, stamp)
+	}
+	for _, s := range []string{"", "yesterday"} {
+		if got := parseTimestamp(s); !got.IsZero() {
+			t.Errorf("parseTimestamp(%q) = %v, want the zero time", s, got)
+		}
+	}
+}
+
+func TestSortTasks(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+	tasks := []Task{
+		{ID: 1, Due: "2025-04-02", CreatedAt: day(3), UpdatedAt: day(3), Status: StatusDone},
+		{ID: 2, CreatedAt: day(1), UpdatedAt: day(5), Status: StatusTodo},
+		{ID: 3, Due: "2025-04-01", CreatedAt: day(2), UpdatedAt: day(2), Status: StatusTodo},
+		{ID: 4, Due: "2025-04-03", CreatedAt: day(1), UpdatedAt: day(4), Status: StatusTodo, Priority: true},
+	}
+
+	tests := []struct {
+		order string
+		want  []int
+	}{
+		{SortDefault, []int{4, 3, 2, 1}},
+		{"", []int{4, 3, 2, 1}},
+		{SortDue, []int{3, 1, 4, 2}},
+		{SortCreated, []int{2, 4, 3, 1}},
+		{SortUpdated, []int{3, 1, 4, 2}},
+	}
+	for _, tt := range tests {
+		sorted := append([]Task(nil), tasks...)
+		if err := SortTasks(sorted, tt.order); err != nil {
+			t.Fatalf("SortTasks(%q): %v", tt.order, err)
+		}
+		if got := ids(sorted); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SortTasks(%q) = %v, want %v", tt.order, got, tt.want)
+		}
+	}
+
+	if err := SortTasks(tasks, "title"); err == nil {
+		t.Error("SortTasks with an unknown order did not return an error")
+	}
+}
@@ -0,0 +1,80 @@
+package task
+
+import (
+	"math"
+	"time"
+)
+
+// Urgency factors reach their full weight at these limits
+const (
+	urgencyDueDays     = 14  // due starts counting this many days before the due date
+	urgencyOverdueDays = 7   // overdue reaches its full weight after this many days
+	urgencyAgeDays     = 365 // age reaches its full weight at this many days old
+)
+
+// UrgencyCoefficients weight each part of a task's urgency score
+type UrgencyCoefficients struct {
+	Priority float64 // added for high priority tasks
+	Due      float64 // scaled by how close the due date is, full when due today or overdue
+	Overdue  float64 // scaled by how many days overdue, full after a week
+	Age      float64 // scaled by how old the task is, full after a year
+	Blocked  float64 // added for blocked tasks, usually negative
+}
+
+// DefaultUrgency is used for any coefficient that is not set in the config
+var DefaultUrgency = UrgencyCoefficients{
+	Priority: 6,
+	Due:      12,
+	Overdue:  4,
+	Age:      2,
+	Blocked:  -5,
+}
+
+// UrgencyTerm is one part of an urgency score, such as the weight given to priority
+type UrgencyTerm struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+}
+
+// Urgency scores how soon a task should be worked on, returning the parts that add up to the score, in the
+// order of UrgencyCoefficients. parts that do not apply to the task are left out. today is the start of the
+// current day, in local time
+func (t Task) Urgency(c UrgencyCoefficients, today time.Time) []UrgencyTerm {
+	var terms []UrgencyTerm
+	add := func(name string, coefficient, factor float64) {
+		if factor > 0 && coefficient != 0 {
+			terms = append(terms, UrgencyTerm{Name: name, Value: coefficient * factor})
+		}
+	}
+
+	if t.Priority {
+		add("priority", c.Priority, 1)
+	}
+
+	// days until the due date, negative once it has passed
+	if due, err := time.ParseInLocation("2006-01-02", t.Due, time.Local); err == nil {
+		days := math.Round(due.Sub(today).Hours() / 24)
+		add("due", c.Due, min(max(1-days/urgencyDueDays, 0), 1))
+		add("overdue", c.Overdue, min(-days/urgencyOverdueDays, 1))
+	}
+
+	if !t.CreatedAt.IsZero() {
+		age := today.Sub(t.CreatedAt).Hours() / 24
+		add("age", c.Age, min(age/urgencyAgeDays, 1))
+	}
+
+	if t.Status == StatusBlocked {
+		add("blocked", c.Blocked, 1)
+	}
+
+	return terms
+}
+
+// UrgencyScore adds up the parts of an urgency score
+func UrgencyScore(terms []UrgencyTerm) float64 {
+	score := 0.0
+	for _, term := range terms {
+		score += term.Value
+	}
+	return score
+}
@@ -0,0 +1,61 @@
+package task
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUrgency(t *testing.T) {
+	today := time.Date(2030, 6, 15, 0, 0, 0, 0, time.Local)
+	due := func(days int) string {
+		return today.AddDate(0, 0, days).Format("2006-01-02")
+	}
+
+	tests := []struct {
+		name string
+		task Task
+		want []UrgencyTerm
+	}{
+		{"nothing applies", Task{}, nil},
+		{"priority", Task{Priority: true}, []UrgencyTerm{{"priority", 6}}},
+		{"due today", Task{Due: due(0)}, []UrgencyTerm{{"due", 12}}},
+		{"due in a week", Task{Due: due(7)}, []UrgencyTerm{{"due", 6}}},
+		{"due in two weeks", Task{Due: due(14)}, nil},
+		{"overdue", Task{Due: due(-7)}, []UrgencyTerm{{"due", 12}, {"overdue", 4}}},
+		{"long overdue", Task{Due: due(-30)}, []UrgencyTerm{{"due", 12}, {"overdue", 4}}},
+		{"half a year old", Task{CreatedAt: today.Add(-365 * 12 * time.Hour)}, []UrgencyTerm{{"age", 1}}},
+		{"years old", Task{CreatedAt: today.AddDate(-3, 0, 0)}, []UrgencyTerm{{"age", 2}}},
+		{"created later today", Task{CreatedAt: today.Add(time.Hour)}, nil},
+		{"blocked", Task{Status: StatusBlocked}, []UrgencyTerm{{"blocked", -5}}},
+		{"invalid due date", Task{Due: "someday"}, nil},
+		{
+			"everything",
+			Task{Priority: true, Due: due(-7), CreatedAt: today.AddDate(-1, 0, 0), Status: StatusBlocked},
+			[]UrgencyTerm{{"priority", 6}, {"due", 12}, {"overdue", 4}, {"age", 2}, {"blocked", -5}},
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.task.Urgency(DefaultUrgency, today); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Urgency = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// a zero coefficient leaves its part out
+	c := DefaultUrgency
+	c.Priority = 0
+	if got := (Task{Priority: true, Due: due(0)}).Urgency(c, today); !reflect.DeepEqual(got, []UrgencyTerm{{"due", 12}}) {
+		t.Errorf("Urgency with no priority weight = %v", got)
+	}
+}
+
+func TestUrgencyScore(t *testing.T) {
+	if got := UrgencyScore(nil); got != 0 {
+		t.Errorf("UrgencyScore(nil) = %v, want 0", got)
+	}
+	terms := []UrgencyTerm{{"priority", 6}, {"due", 8.5}, {"blocked", -5}}
+	if got := UrgencyScore(terms); math.Abs(got-9.5) > 1e-9 {
+		t.Errorf("UrgencyScore = %v, want 9.5", got)
+	}
+}
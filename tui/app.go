@@ -0,0 +1,353 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tm-craggs/tidytask/task"
+	"github.com/tm-craggs/tidytask/util"
+)
+
+// mode is what key presses currently do
+type mode int
+
+const (
+	modeList    mode = iota // keys move around the table and act on the selected task
+	modeFilter              // keys edit the filter, which is applied as it is typed
+	modePrompt              // keys edit the answer to a prompt, such as a new title
+	modeConfirm             // y confirms an action, any other key cancels it
+)
+
+// app holds the state of the interface
+type app struct {
+	store task.Store
+	term  *terminal
+
+	all    []task.Task // every task, in display order
+	tasks  []task.Task // tasks matching the filter, as shown in the table
+	cursor int         // index in tasks of the selected task
+	offset int         // index in tasks of the first row shown
+
+	filter string
+	detail bool // show the detail pane
+	help   bool // show every key in the help line
+
+	mode      mode
+	prompt    string             // question shown in modePrompt and modeConfirm
+	input     []rune             // answer typed in modePrompt
+	onInput   func(string) error // called with the answer when a prompt is submitted
+	onConfirm func() error       // called when an action is confirmed
+	message   string             // result of the last action, shown until the next key press
+}
+
+// Run opens the interface on the terminal and returns when the user quits.
+// it returns ErrNotTerminal if stdin or stdout is not a terminal
+func Run(store task.Store) error {
+	t, err := openTerminal()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = t.close() }()
+
+	a := &app{store: store, term: t}
+	if err := a.reload(); err != nil {
+		return err
+	}
+
+	for {
+		if err := a.render(); err != nil {
+			return err
+		}
+		k, err := t.readKey()
+		if err != nil {
+			return err
+		}
+		if quit := a.handle(k); quit {
+			return nil
+		}
+	}
+}
+
+// reload reads every task from the store and applies the filter, keeping the same task selected if it still shows
+func (a *app) reload() error {
+	tasks, err := a.store.List(task.Filter{})
+	if err != nil {
+		return fmt.Errorf("failed to retrieve tasks: %w", err)
+	}
+	a.all = tasks
+	a.applyFilter()
+	return nil
+}
+
+// applyFilter chooses the tasks to show. every word of the filter must appear in the task's title, tags,
+// status or handle, ignoring case
+func (a *app) applyFilter() {
+	selected := -1
+	if t, ok := a.selected(); ok {
+		selected = t.ID
+	}
+
+	words := strings.Fields(strings.ToLower(a.filter))
+	a.tasks = a.tasks[:0]
+	for _, t := range a.all {
+		text := strings.ToLower(strings.Join(append([]string{t.Title, t.Status, t.Handle}, t.Tags...), " "))
+		if !slices.ContainsFunc(words, func(w string) bool { return !strings.Contains(text, w) }) {
+			a.tasks = append(a.tasks, t)
+		}
+	}
+
+	a.selectID(selected)
+}
+
+// selected returns the selected task, if any task is shown
+func (a *app) selected() (task.Task, bool) {
+	if a.cursor < 0 || a.cursor >= len(a.tasks) {
+		return task.Task{}, false
+	}
+	return a.tasks[a.cursor], true
+}
+
+// selectID moves the cursor to the task with the given ID, or keeps it in range if that task is not shown
+func (a *app) selectID(id int) {
+	if i := slices.IndexFunc(a.tasks, func(t task.Task) bool { return t.ID == id }); i >= 0 {
+		a.cursor = i
+	}
+	a.cursor = max(0, min(a.cursor, len(a.tasks)-1))
+}
+
+// handle acts on a key press, returning true when the user quits
+func (a *app) handle(k key) bool {
+	if k.name == "ctrl-c" {
+		return true
+	}
+	a.message = ""
+
+	switch a.mode {
+	case modeFilter:
+		a.handleFilter(k)
+	case modePrompt:
+		a.handlePrompt(k)
+	case modeConfirm:
+		a.mode = modeList
+		if k.r == 'y' || k.r == 'Y' {
+			a.report(a.onConfirm())
+		} else {
+			a.message = "Cancelled"
+		}
+	default:
+		return a.handleList(k)
+	}
+	return false
+}
+
+// handleList moves around the table and starts actions on the selected task
+func (a *app) handleList(k key) bool {
+	_, height := a.term.size()
+	page := max(1, a.listHeight(height)-1)
+
+	switch {
+	case k.r == 'q':
+		return true
+	case k.name == "up" || k.r == 'k':
+		a.cursor = max(0, a.cursor-1)
+	case k.name == "down" || k.r == 'j':
+		a.cursor = max(0, min(len(a.tasks)-1, a.cursor+1))
+	case k.name == "pgup":
+		a.cursor = max(0, a.cursor-page)
+	case k.name == "pgdown":
+		a.cursor = max(0, min(len(a.tasks)-1, a.cursor+page))
+	case k.name == "home" || k.r == 'g':
+		a.cursor = 0
+	case k.name == "end" || k.r == 'G':
+		a.cursor = max(0, len(a.tasks)-1)
+	case k.name == "enter" || k.name == "tab":
+		a.detail = !a.detail
+	case k.r == '?':
+		a.help = !a.help
+	case k.r == '/':
+		a.mode = modeFilter
+	case k.name == "esc" && a.filter != "":
+		a.filter = ""
+		a.applyFilter()
+	case k.r == 'a':
+		a.ask("Add task: ", "", a.add)
+	case k.r == 'u':
+		a.confirm("Undo the last change?", a.undo)
+	default:
+		return a.handleTask(k)
+	}
+	return false
+}
+
+// handleTask starts an action on the selected task
+func (a *app) handleTask(k key) bool {
+	t, ok := a.selected()
+	if !ok {
+		return false
+	}
+
+	switch k.r {
+	case 'c':
+		if t.Complete {
+			a.message = fmt.Sprintf("Task %d is already complete", t.ID)
+			return false
+		}
+		a.report(a.change(func() error { return a.store.Complete(t.ID) }, "Completed task %d", t.ID))
+	case 'o':
+		if !t.Closed() {
+			a.message = fmt.Sprintf("Task %d is already open", t.ID)
+			return false
+		}
+		a.report(a.change(func() error { return a.store.Reopen(t.ID) }, "Reopened task %d", t.ID))
+	case 'p':
+		t.Priority = !t.Priority
+		a.report(a.change(func() error { return a.store.Update(t) }, "Changed priority of task %d", t.ID))
+	case 'e':
+		a.ask("Title: ", t.Title, func(title string) error {
+			if title = strings.TrimSpace(title); title == "" {
+				return fmt.Errorf("title cannot be empty")
+			}
+			t.Title = title
+			return a.change(func() error { return a.store.Update(t) }, "Updated task %d", t.ID)
+		})
+	case 't':
+		a.ask("Due (YYYY-MM-DD, tomorrow, +1w, or empty for none): ", t.Due, func(due string) error {
+			if due = strings.TrimSpace(due); due != "" {
+				var err error
+				if due, err = util.ParseDate(due); err != nil {
+					return err
+				}
+			}
+			t.Due = due
+			return a.change(func() error { return a.store.Update(t) }, "Updated task %d", t.ID)
+		})
+	case 'd', 'x':
+		a.confirm(fmt.Sprintf("Delete task %d %q?", t.ID, t.Title), func() error {
+			return a.change(func() error { return a.store.Delete(t.ID) }, "Removed task %d", t.ID)
+		})
+	}
+	return false
+}
+
+// handleFilter edits the filter, applying it as it changes
+func (a *app) handleFilter(k key) {
+	switch k.name {
+	case "enter":
+		a.mode = modeList
+		return
+	case "esc":
+		a.mode = modeList
+		a.filter = ""
+	case "backspace":
+		if r := []rune(a.filter); len(r) > 0 {
+			a.filter = string(r[:len(r)-1])
+		}
+	case "ctrl-u":
+		a.filter = ""
+	case "":
+		if k.r != 0 {
+			a.filter += string(k.r)
+		}
+	}
+	a.applyFilter()
+}
+
+// handlePrompt edits the answer to a prompt, submitting it on enter
+func (a *app) handlePrompt(k key) {
+	switch k.name {
+	case "enter":
+		a.mode = modeList
+		a.report(a.onInput(string(a.input)))
+	case "esc":
+		a.mode = modeList
+		a.message = "Cancelled"
+	case "backspace":
+		if len(a.input) > 0 {
+			a.input = a.input[:len(a.input)-1]
+		}
+	case "ctrl-u":
+		a.input = nil
+	case "":
+		if k.r != 0 {
+			a.input = append(a.input, k.r)
+		}
+	}
+}
+
+// ask shows a prompt, starting with initial as the answer, and calls submit with the answer
+func (a *app) ask(prompt, initial string, submit func(string) error) {
+	a.mode = modePrompt
+	a.prompt = prompt
+	a.input = []rune(initial)
+	a.onInput = submit
+}
+
+// confirm asks the user to confirm an action before running it
+func (a *app) confirm(prompt string, action func() error) {
+	a.mode = modeConfirm
+	a.prompt = prompt + " [y/N]"
+	a.onConfirm = action
+}
+
+// report shows an error from an action, if there was one
+func (a *app) report(err error) {
+	if err != nil {
+		a.message = "Error: " + err.Error()
+	}
+}
+
+// change backs up the tasks, runs fn, reloads the tasks and reports success with a formatted message
+func (a *app) change(fn func() error, format string, args ...any) error {
+	warning := a.backup()
+	if err := fn(); err != nil {
+		return err
+	}
+	if err := a.reload(); err != nil {
+		return err
+	}
+
+	a.message = fmt.Sprintf(format, args...) + warning
+	return nil
+}
+
+// backup saves a copy of the tasks for undo. as with other commands, a failed backup does not stop a change,
+// so it returns a warning to add to the message instead of an error
+func (a *app) backup() string {
+	if err := a.store.Backup(); err != nil {
+		return fmt.Sprintf(" (warning: failed to back up database: %v)", err)
+	}
+	return ""
+}
+
+// add adds a task with the given title and selects it
+func (a *app) add(title string) error {
+	if title = strings.TrimSpace(title); title == "" {
+		return fmt.Errorf("title cannot be empty")
+	}
+
+	warning := a.backup()
+	id, err := a.store.Add(task.Task{Title: title})
+	if err != nil {
+		return fmt.Errorf("failed to add task: %w", err)
+	}
+	if err := a.reload(); err != nil {
+		return err
+	}
+
+	a.selectID(id)
+	a.message = fmt.Sprintf("Added task %d", id) + warning
+	return nil
+}
+
+// undo restores the backup taken before the last change
+func (a *app) undo() error {
+	if err := a.store.Restore(); err != nil {
+		return fmt.Errorf("no backup found: %w", err)
+	}
+	if err := a.reload(); err != nil {
+		return err
+	}
+	a.message = "Undid the last change"
+	return nil
+}
@@ -0,0 +1,311 @@
+package tui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/tm-craggs/tidytask/task"
+)
+
+// newApp returns an app showing tasks from a MemoryStore, drawing to out. the screen is 80x24, as tests have no
+// terminal
+func newApp(t *testing.T, out *bytes.Buffer, tasks ...task.Task) *app {
+	t.Helper()
+	store := task.NewMemoryStore()
+	for _, tk := range tasks {
+		if _, err := store.Add(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	a := &app{store: store, term: newTerminal("", out)}
+	if err := a.reload(); err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+// press handles each key in turn, failing the test if a key quits
+func press(t *testing.T, a *app, keys ...key) {
+	t.Helper()
+	for _, k := range keys {
+		if a.handle(k) {
+			t.Fatalf("key %+v quit", k)
+		}
+	}
+}
+
+// typed returns a key press for each rune of s
+func typed(s string) []key {
+	var keys []key
+	for _, r := range s {
+		keys = append(keys, key{r: r})
+	}
+	return keys
+}
+
+var (
+	enter = key{name: "enter"}
+	esc   = key{name: "esc"}
+	down  = key{name: "down"}
+)
+
+// selectedTitle returns the title of the selected task, or "" if none is shown
+func selectedTitle(a *app) string {
+	t, _ := a.selected()
+	return t.Title
+}
+
+func TestAppMove(t *testing.T) {
+	a := newApp(t, nil, task.Task{Title: "One"}, task.Task{Title: "Two"}, task.Task{Title: "Three"})
+
+	tests := []struct {
+		k    key
+		want string
+	}{
+		{down, "Two"},
+		{key{r: 'j'}, "Three"},
+		{down, "Three"},
+		{key{r: 'k'}, "Two"},
+		{key{r: 'g'}, "One"},
+		{key{name: "up"}, "One"},
+		{key{r: 'G'}, "Three"},
+		{key{name: "home"}, "One"},
+		{key{name: "pgdown"}, "Three"},
+		{key{name: "pgup"}, "One"},
+	}
+	for _, tt := range tests {
+		press(t, a, tt.k)
+		if got := selectedTitle(a); got != tt.want {
+			t.Errorf("after %+v selected %q, want %q", tt.k, got, tt.want)
+		}
+	}
+
+	if !a.handle(key{r: 'q'}) || !a.handle(key{name: "ctrl-c"}) {
+		t.Error("q and ctrl-c should quit")
+	}
+}
+
+func TestAppFilter(t *testing.T) {
+	a := newApp(t, nil,
+		task.Task{Title: "Write report", Tags: []string{"work"}},
+		task.Task{Title: "Buy milk"},
+		task.Task{Title: "Report bug", Tags: []string{"home"}},
+	)
+	press(t, a, key{r: 'G'})
+
+	// the filter is applied as it is typed, matching titles and tags, and keeps the selection if it still shows
+	press(t, a, key{r: '/'})
+	press(t, a, typed("REPORT")...)
+	if len(a.tasks) != 2 || selectedTitle(a) != "Report bug" {
+		t.Errorf("filter %q shows %d tasks, selected %q", a.filter, len(a.tasks), selectedTitle(a))
+	}
+	press(t, a, typed(" work")...)
+	if len(a.tasks) != 1 || selectedTitle(a) != "Write report" {
+		t.Errorf("filter %q shows %d tasks, selected %q", a.filter, len(a.tasks), selectedTitle(a))
+	}
+	// handles are random, so the filter uses a character no handle contains
+	press(t, a, key{name: "backspace"}, key{name: "backspace"}, key{name: "backspace"}, key{name: "backspace"}, key{r: '!'})
+	if a.filter != "REPORT !" || len(a.tasks) != 0 {
+		t.Errorf("filter %q shows %d tasks", a.filter, len(a.tasks))
+	}
+
+	// enter keeps the filter, esc in the table clears it
+	press(t, a, key{name: "ctrl-u"})
+	press(t, a, typed("milk")...)
+	press(t, a, enter)
+	if a.mode != modeList || a.filter != "milk" || len(a.tasks) != 1 {
+		t.Errorf("after enter: mode %v, filter %q, %d tasks", a.mode, a.filter, len(a.tasks))
+	}
+	press(t, a, esc)
+	if a.filter != "" || len(a.tasks) != 3 {
+		t.Errorf("after esc: filter %q, %d tasks", a.filter, len(a.tasks))
+	}
+}
+
+func TestAppActions(t *testing.T) {
+	a := newApp(t, nil, task.Task{Title: "One"}, task.Task{Title: "Two"})
+	get := func(id int) task.Task {
+		t.Helper()
+		tk, err := a.store.Get(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tk
+	}
+
+	press(t, a, key{r: 'c'})
+	if !get(1).Complete || a.message != "Completed task 1" {
+		t.Errorf("complete: task %+v, message %q", get(1), a.message)
+	}
+
+	// the completed task moves below open tasks and stays selected
+	if selectedTitle(a) != "One" {
+		t.Errorf("selected %q after completing, want One", selectedTitle(a))
+	}
+	press(t, a, key{r: 'c'})
+	if a.message != "Task 1 is already complete" {
+		t.Errorf("message = %q", a.message)
+	}
+	press(t, a, key{r: 'o'})
+	if get(1).Complete {
+		t.Error("o did not reopen task 1")
+	}
+
+	press(t, a, key{r: 'p'})
+	if !get(1).Priority {
+		t.Error("p did not set the priority")
+	}
+
+	// u undoes the last change, after confirmation
+	press(t, a, key{r: 'u'}, key{r: 'y'})
+	if get(1).Priority || a.message != "Undid the last change" {
+		t.Errorf("undo: task %+v, message %q", get(1), a.message)
+	}
+}
+
+func TestAppPrompts(t *testing.T) {
+	a := newApp(t, nil, task.Task{Title: "One"})
+
+	// e starts with the current title
+	press(t, a, key{r: 'e'})
+	if a.mode != modePrompt || string(a.input) != "One" {
+		t.Fatalf("mode %v, input %q", a.mode, string(a.input))
+	}
+	press(t, a, key{name: "ctrl-u"})
+	press(t, a, typed("Uno")...)
+	press(t, a, enter)
+	if tk, _ := a.store.Get(1); tk.Title != "Uno" {
+		t.Errorf("title = %q, want Uno", tk.Title)
+	}
+
+	// an empty title is refused
+	press(t, a, key{r: 'e'}, key{name: "ctrl-u"}, enter)
+	if tk, _ := a.store.Get(1); tk.Title != "Uno" || a.message != "Error: title cannot be empty" {
+		t.Errorf("title = %q, message %q", tk.Title, a.message)
+	}
+
+	// esc cancels a prompt
+	press(t, a, key{r: 'e'}, key{r: 'x'}, esc)
+	if tk, _ := a.store.Get(1); tk.Title != "Uno" || a.message != "Cancelled" {
+		t.Errorf("title = %q, message %q", tk.Title, a.message)
+	}
+
+	press(t, a, key{r: 't'})
+	press(t, a, typed("2030-01-02")...)
+	press(t, a, enter)
+	if tk, _ := a.store.Get(1); tk.Due != "2030-01-02" {
+		t.Errorf("due = %q, want 2030-01-02", tk.Due)
+	}
+	press(t, a, key{r: 't'}, key{name: "ctrl-u"})
+	press(t, a, typed("someday")...)
+	press(t, a, enter)
+	if tk, _ := a.store.Get(1); tk.Due != "2030-01-02" || !strings.HasPrefix(a.message, "Error: ") {
+		t.Errorf("due = %q, message %q", tk.Due, a.message)
+	}
+
+	// a new task is selected once added
+	press(t, a, key{r: 'a'})
+	press(t, a, typed("  Two ")...)
+	press(t, a, enter)
+	if selectedTitle(a) != "Two" || a.message != "Added task 2" {
+		t.Errorf("selected %q, message %q", selectedTitle(a), a.message)
+	}
+}
+
+func TestAppDelete(t *testing.T) {
+	a := newApp(t, nil, task.Task{Title: "One"}, task.Task{Title: "Two"})
+
+	// any key but y cancels
+	press(t, a, key{r: 'd'}, key{r: 'n'})
+	if len(a.all) != 2 || a.message != "Cancelled" {
+		t.Errorf("%d tasks, message %q", len(a.all), a.message)
+	}
+
+	press(t, a, key{r: 'x'})
+	if a.mode != modeConfirm || a.prompt != `Delete task 1 "One"? [y/N]` {
+		t.Errorf("mode %v, prompt %q", a.mode, a.prompt)
+	}
+	press(t, a, key{r: 'Y'})
+	if len(a.all) != 1 || selectedTitle(a) != "Two" {
+		t.Errorf("%d tasks, selected %q", len(a.all), selectedTitle(a))
+	}
+
+	// keys that act on a task do nothing with no task shown
+	press(t, a, key{r: 'd'}, key{r: 'y'})
+	press(t, a, key{r: 'd'}, key{r: 'y'})
+	if len(a.all) != 0 {
+		t.Fatalf("%d tasks left", len(a.all))
+	}
+	press(t, a, key{r: 'c'}, key{r: 'e'})
+	if a.mode != modeList {
+		t.Errorf("mode %v with no tasks", a.mode)
+	}
+}
+
+func TestAppRender(t *testing.T) {
+	var out bytes.Buffer
+	a := newApp(t, &out, task.Task{Title: "Write report", Tags: []string{"work"}}, task.Task{Title: "Buy milk"})
+
+	if err := a.render(); err != nil {
+		t.Fatal(err)
+	}
+	screen := out.String()
+	for _, want := range []string{"TidyTask  2 tasks, 2 open", "Title", "Write report", "Buy milk", helpShort[:40]} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("screen does not contain %q:\n%s", want, screen)
+		}
+	}
+	if lines := strings.Count(screen, "\r\n") + 1; lines != 24 {
+		t.Errorf("screen has %d lines, want 24", lines)
+	}
+
+	// the detail pane shows the selected task
+	out.Reset()
+	press(t, a, enter, key{r: '?'})
+	if err := a.render(); err != nil {
+		t.Fatal(err)
+	}
+	screen = out.String()
+	tk := a.all[0]
+	for _, want := range []string{"ID:        1 (handle " + tk.Handle + ")", "Tags:      work", "Completed: -", helpLong[:20]} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("screen does not contain %q:\n%s", want, screen)
+		}
+	}
+
+	// an empty table is explained
+	press(t, a, key{r: '/'})
+	press(t, a, typed("!!")...)
+	if lines := a.table(80, 3); !strings.Contains(strings.Join(lines, "\n"), "No tasks match the filter") || len(lines) != 4 {
+		t.Errorf("table = %q", lines)
+	}
+	if got := a.titleBar(); !strings.Contains(got, "filter: !!  (0 shown)") {
+		t.Errorf("title bar = %q", got)
+	}
+}
+
+func TestTaskTable(t *testing.T) {
+	tasks := []task.Task{{ID: 12, Title: strings.Repeat("long title ", 20), Status: task.StatusTodo}}
+	header, lines := taskTable(tasks, 60, func(task.Task) string { return "[ ] " })
+
+	if len(lines) != 1 || !strings.Contains(lines[0], "[ ] 12") || !strings.Contains(lines[0], "…") {
+		t.Errorf("lines = %q", lines)
+	}
+	if visibleWidth(header) != visibleWidth(lines[0]) || visibleWidth(header) > 60 {
+		t.Errorf("header is %d columns, row is %d, want at most 60", visibleWidth(header), visibleWidth(lines[0]))
+	}
+}
+
+func TestTypingLine(t *testing.T) {
+	if got := typingLine("short", 10); got != "short" {
+		t.Errorf("typingLine = %q", got)
+	}
+	if got := typingLine("0123456789abc", 10); got != "…456789abc" {
+		t.Errorf("typingLine = %q, want the end of the line", got)
+	}
+	if got := pad("\x1b[1mab\x1b[0m", 4); visibleWidth(got) != 4 || !strings.HasSuffix(got, "  ") {
+		t.Errorf("pad = %q", got)
+	}
+}
@@ -0,0 +1,6 @@
+// Package tui implements the full-screen terminal interface opened by 'tidytask ui'.
+//
+// Tasks are shown in a table that can be navigated and filtered with the keyboard, with a detail pane for the
+// selected task. Every change goes through a task.Store and is backed up first, so 'tidytask undo' works as it
+// does after any other command.
+package tui
@@ -0,0 +1,183 @@
+package tui
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/tm-craggs/tidytask/task"
+)
+
+// ErrCancelled is returned by Pick when the user cancels instead of choosing tasks
+var ErrCancelled = errors.New("cancelled")
+
+// picker holds the state of Pick
+type picker struct {
+	term   *terminal
+	title  string
+	multi  bool
+	all    []task.Task  // tasks to choose from
+	tasks  []task.Task  // tasks matching the filter
+	chosen map[int]bool // IDs of the chosen tasks, including any hidden by the filter
+	cursor int
+	offset int
+	filter string
+}
+
+// Pick shows tasks on the terminal and lets the user choose from them, returning the IDs of the chosen tasks in
+// the order they were given. Typing filters the tasks, the arrow keys move, and enter confirms. When multi is
+// true, space chooses several tasks, and enter with none chosen picks the task under the cursor.
+//
+// It returns ErrNotTerminal if stdin or stdout is not a terminal, and ErrCancelled if the user presses escape.
+func Pick(tasks []task.Task, title string, multi bool) ([]int, error) {
+	t, err := openTerminal()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = t.close() }()
+
+	p := &picker{term: t, title: title, multi: multi, all: tasks, chosen: make(map[int]bool)}
+	p.applyFilter()
+
+	for {
+		if err := p.render(); err != nil {
+			return nil, err
+		}
+		k, err := t.readKey()
+		if err != nil {
+			return nil, err
+		}
+		if ids, err := p.handle(k); ids != nil || err != nil {
+			return ids, err
+		}
+	}
+}
+
+// handle acts on a key press, returning the chosen IDs once the user confirms, or ErrCancelled
+func (p *picker) handle(k key) ([]int, error) {
+	switch k.name {
+	case "ctrl-c", "esc":
+		return nil, ErrCancelled
+	case "enter":
+		return p.result(), nil
+	case "up":
+		p.cursor = max(0, p.cursor-1)
+	case "down":
+		p.cursor = max(0, min(len(p.tasks)-1, p.cursor+1))
+	case "pgup", "home":
+		p.cursor = 0
+	case "pgdown", "end":
+		p.cursor = max(0, len(p.tasks)-1)
+	case "backspace":
+		if r := []rune(p.filter); len(r) > 0 {
+			p.filter = string(r[:len(r)-1])
+			p.applyFilter()
+		}
+	case "ctrl-u":
+		p.filter = ""
+		p.applyFilter()
+	case "":
+		switch {
+		case k.r == ' ' && p.multi:
+			if cur, ok := p.current(); ok {
+				p.chosen[cur.ID] = !p.chosen[cur.ID]
+				p.cursor = min(len(p.tasks)-1, p.cursor+1)
+			}
+		case k.r != 0 && k.r != ' ':
+			p.filter += string(k.r)
+			p.applyFilter()
+		}
+	}
+	return nil, nil
+}
+
+// result returns the chosen IDs in task order, or the task under the cursor if none are chosen
+func (p *picker) result() []int {
+	var ids []int
+	if p.multi {
+		for _, t := range p.all {
+			if p.chosen[t.ID] {
+				ids = append(ids, t.ID)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		if cur, ok := p.current(); ok {
+			ids = []int{cur.ID}
+		}
+	}
+	return ids
+}
+
+// current returns the task under the cursor, if any task is shown
+func (p *picker) current() (task.Task, bool) {
+	if p.cursor < 0 || p.cursor >= len(p.tasks) {
+		return task.Task{}, false
+	}
+	return p.tasks[p.cursor], true
+}
+
+// applyFilter shows the tasks whose title, tags or handle contain every word of the filter, ignoring case
+func (p *picker) applyFilter() {
+	words := strings.Fields(strings.ToLower(p.filter))
+	p.tasks = p.tasks[:0]
+	for _, t := range p.all {
+		text := strings.ToLower(strings.Join(append([]string{t.Title, t.Handle}, t.Tags...), " "))
+		if !slices.ContainsFunc(words, func(w string) bool { return !strings.Contains(text, w) }) {
+			p.tasks = append(p.tasks, t)
+		}
+	}
+	p.cursor = max(0, min(p.cursor, len(p.tasks)-1))
+}
+
+// render draws the picker over the whole screen
+func (p *picker) render() error {
+	width, height := p.term.size()
+	rows := max(1, height-4)
+
+	// scroll so the cursor is shown
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	}
+	if p.cursor >= p.offset+rows {
+		p.offset = p.cursor - rows + 1
+	}
+	p.offset = max(0, min(p.offset, len(p.tasks)-rows))
+	shown := p.tasks[p.offset:min(len(p.tasks), p.offset+rows)]
+
+	// a checkbox shows which tasks are chosen when choosing several
+	var gutter func(t task.Task) string
+	keys := "↑↓ move  enter choose  esc cancel  type to filter"
+	if p.multi {
+		gutter = func(t task.Task) string {
+			if p.chosen[t.ID] {
+				return "[x] "
+			}
+			return "[ ] "
+		}
+		keys = "↑↓ move  space select  enter confirm  esc cancel  type to filter"
+	}
+
+	lines := []string{
+		styleReverse + pad(runewidth.Truncate(" "+p.title, width, "…"), width) + styleReset,
+		typingLine(" Filter: "+p.filter+"█", width),
+	}
+	header, rowLines := taskTable(shown, width, gutter)
+	lines = append(lines, header)
+	for i, line := range rowLines {
+		if p.offset+i == p.cursor {
+			line = highlight(line, width)
+		}
+		lines = append(lines, line)
+	}
+	if len(shown) == 0 {
+		lines = append(lines, " No tasks match the filter.")
+	}
+	for len(lines) < rows+3 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, runewidth.Truncate(" "+keys, width, "…"))
+
+	return p.term.draw(lines)
+}
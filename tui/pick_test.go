@@ -0,0 +1,131 @@
+package tui
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tm-craggs/tidytask/task"
+)
+
+// newPicker returns a picker choosing from tasks, numbered from 1, drawing to out
+func newPicker(out *bytes.Buffer, multi bool, titles ...string) *picker {
+	var tasks []task.Task
+	for i, title := range titles {
+		tasks = append(tasks, task.Task{ID: i + 1, Title: title, Handle: "h" + title, Status: task.StatusTodo})
+	}
+	p := &picker{term: newTerminal("", out), title: "Choose", multi: multi, all: tasks, chosen: make(map[int]bool)}
+	p.applyFilter()
+	return p
+}
+
+// pick handles each key in turn, returning the result of the last key
+func pick(p *picker, keys ...key) ([]int, error) {
+	var ids []int
+	var err error
+	for _, k := range keys {
+		ids, err = p.handle(k)
+	}
+	return ids, err
+}
+
+func TestPickOne(t *testing.T) {
+	p := newPicker(nil, false, "one", "two", "three")
+
+	// space does not choose when picking one task, it is ignored rather than typed
+	ids, err := pick(p, down, key{r: ' '}, down, key{name: "up"}, enter)
+	if err != nil || !reflect.DeepEqual(ids, []int{2}) {
+		t.Errorf("picked %v, %v; want [2]", ids, err)
+	}
+	if p.filter != "" {
+		t.Errorf("filter = %q", p.filter)
+	}
+
+	if ids, err := pick(p, key{name: "end"}, enter); !reflect.DeepEqual(ids, []int{3}) || err != nil {
+		t.Errorf("end picked %v, %v; want [3]", ids, err)
+	}
+	if _, err := pick(p, esc); !errors.Is(err, ErrCancelled) {
+		t.Errorf("esc error = %v, want ErrCancelled", err)
+	}
+	if _, err := pick(p, key{name: "ctrl-c"}); !errors.Is(err, ErrCancelled) {
+		t.Errorf("ctrl-c error = %v, want ErrCancelled", err)
+	}
+}
+
+func TestPickMany(t *testing.T) {
+	p := newPicker(nil, true, "one", "two", "three")
+
+	// enter with nothing chosen picks the task under the cursor
+	if ids, _ := pick(p, down, enter); !reflect.DeepEqual(ids, []int{2}) {
+		t.Errorf("picked %v, want [2]", ids)
+	}
+
+	// space chooses and moves down, choices hidden by the filter are kept, and IDs are in task order
+	ids, err := pick(p, key{r: ' '}, key{name: "home"}, key{r: ' '})
+	if ids != nil || err != nil {
+		t.Fatalf("space returned %v, %v", ids, err)
+	}
+	ids, _ = pick(p, key{r: 'o'}, key{r: 'n'}, enter)
+	if !reflect.DeepEqual(ids, []int{1, 2}) {
+		t.Errorf("picked %v, want [1 2]", ids)
+	}
+
+	// space again removes a choice
+	ids, _ = pick(p, key{name: "ctrl-u"}, key{name: "home"}, key{r: ' '}, enter)
+	if !reflect.DeepEqual(ids, []int{2}) {
+		t.Errorf("picked %v, want [2]", ids)
+	}
+}
+
+func TestPickFilter(t *testing.T) {
+	p := newPicker(nil, false, "Write report", "Buy milk", "Report bug")
+
+	pick(p, typed("REP")...)
+	if len(p.tasks) != 2 {
+		t.Errorf("filter %q shows %d tasks, want 2", p.filter, len(p.tasks))
+	}
+
+	// handles are matched too
+	pick(p, key{name: "ctrl-u"})
+	pick(p, typed("hbuy")...)
+	if len(p.tasks) != 1 || p.tasks[0].ID != 2 {
+		t.Errorf("filter %q shows %v", p.filter, p.tasks)
+	}
+
+	// with nothing shown, enter picks nothing
+	pick(p, key{r: 'z'})
+	if ids, err := pick(p, enter); ids != nil || err != nil {
+		t.Errorf("enter with nothing shown returned %v, %v", ids, err)
+	}
+	pick(p, key{name: "backspace"})
+	if p.filter != "hbuy" || len(p.tasks) != 1 {
+		t.Errorf("after backspace filter %q shows %d tasks", p.filter, len(p.tasks))
+	}
+}
+
+func TestPickRender(t *testing.T) {
+	var out bytes.Buffer
+	p := newPicker(&out, true, "one", "two")
+	pick(p, key{r: ' '})
+
+	if err := p.render(); err != nil {
+		t.Fatal(err)
+	}
+	screen := out.String()
+	for _, want := range []string{"Choose", "Filter: █", "[x] 1", "[ ] 2", "space select"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("screen does not contain %q:\n%s", want, screen)
+		}
+	}
+
+	out.Reset()
+	pick(p, typed("zzz")...)
+	if err := p.render(); err != nil {
+		t.Fatal(err)
+	}
+	if screen := out.String(); !strings.Contains(screen, "No tasks match the filter.") {
+		t.Errorf("screen does not explain the empty list:\n%s", screen)
+	}
+}
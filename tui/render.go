@@ -0,0 +1,239 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/tm-craggs/tidytask/task"
+	"github.com/tm-craggs/tidytask/util"
+)
+
+// ansiStyle matches the colour codes added by util.FormatTask, which take up no space on screen
+var ansiStyle = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// detailHeight is the number of lines used by the detail pane, including its separator
+const detailHeight = 10
+
+// Screen styles, the reverse style is reapplied after every reset so colours inside a row keep it
+const (
+	styleReset   = "\x1b[0m"
+	styleBold    = "\x1b[1m"
+	styleReverse = "\x1b[7m"
+)
+
+// helpShort and helpLong list the keys in modeList, the long form is shown after pressing ?
+const (
+	helpShort = "a add  e edit  c complete  d delete  / filter  enter details  ? more keys  q quit"
+	helpLong  = "↑↓/jk move  g/G top/bottom  a add  e title  t due  p priority  c complete  o reopen  " +
+		"d delete  u undo  / filter  esc clear filter  enter details  q quit"
+)
+
+// listHeight returns the number of table rows that fit on a screen of the given height
+func (a *app) listHeight(height int) int {
+	// the title bar, column headers and help line are always shown
+	rows := height - 3
+	if a.detail {
+		rows -= detailHeight
+	}
+	return max(1, rows)
+}
+
+// render draws the whole screen
+func (a *app) render() error {
+	width, height := a.term.size()
+	rows := a.listHeight(height)
+
+	// scroll so the selected task is shown
+	if a.cursor < a.offset {
+		a.offset = a.cursor
+	}
+	if a.cursor >= a.offset+rows {
+		a.offset = a.cursor - rows + 1
+	}
+	a.offset = max(0, min(a.offset, len(a.tasks)-rows))
+
+	var lines []string
+	lines = append(lines, styleReverse+pad(runewidth.Truncate(a.titleBar(), width, "…"), width)+styleReset)
+	lines = append(lines, a.table(width, rows)...)
+	if a.detail {
+		lines = append(lines, a.detailPane(width)...)
+	}
+	lines = append(lines, a.statusLine(width))
+
+	return a.term.draw(lines)
+}
+
+// titleBar describes the tasks shown and the filter
+func (a *app) titleBar() string {
+	open := 0
+	for _, t := range a.all {
+		if !t.Closed() {
+			open++
+		}
+	}
+
+	bar := fmt.Sprintf(" TidyTask  %d tasks, %d open", len(a.all), open)
+	if a.filter != "" || a.mode == modeFilter {
+		bar += fmt.Sprintf("  │  filter: %s  (%d shown)", a.filter, len(a.tasks))
+	}
+	return bar
+}
+
+// table returns the column headers followed by exactly rows lines of tasks, coloured as by util.PrintTasks
+func (a *app) table(width, rows int) []string {
+	shown := a.tasks[a.offset:min(len(a.tasks), a.offset+rows)]
+
+	header, lines := taskTable(shown, width, nil)
+	for i := range lines {
+		if a.offset+i == a.cursor {
+			lines[i] = highlight(lines[i], width)
+		}
+	}
+	lines = append([]string{header}, lines...)
+
+	// explain an empty table
+	if len(shown) == 0 {
+		if len(a.all) == 0 {
+			lines = append(lines, " No tasks. Press a to add one.")
+		} else {
+			lines = append(lines, " No tasks match the filter. Press esc to clear it.")
+		}
+	}
+
+	// fill the rest of the table area
+	for len(lines) < rows+1 {
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+// taskTable formats tasks as a bold header and one line per task, with columns sized to fit width.
+// gutter, if not nil, returns a fixed width column to show before each task, such as a checkbox
+func taskTable(tasks []task.Task, width int, gutter func(t task.Task) string) (string, []string) {
+	if gutter == nil {
+		gutter = func(task.Task) string { return "" }
+	}
+	gutterWidth := visibleWidth(gutter(task.Task{}))
+
+	// size the columns to the tasks, the title takes the remaining width
+	idWidth, dueWidth, statusWidth := len("ID"), len("Due"), len("Status")
+	for _, t := range tasks {
+		_, due, status, _ := util.FormatTask(t)
+		idWidth = max(idWidth, len(strconv.Itoa(t.ID)))
+		dueWidth = max(dueWidth, visibleWidth(due))
+		statusWidth = max(statusWidth, visibleWidth(status))
+	}
+	priorityWidth := len("Priority")
+	titleWidth := max(10, width-gutterWidth-idWidth-dueWidth-statusWidth-priorityWidth-10)
+
+	row := func(id, title, due, status, priority string) string {
+		return pad(id, idWidth) + "  " + pad(title, titleWidth) + "  " + pad(due, dueWidth) + "  " +
+			pad(status, statusWidth) + "  " + pad(priority, priorityWidth)
+	}
+
+	header := styleBold + " " + strings.Repeat(" ", gutterWidth) + row("ID", "Title", "Due", "Status", "Priority") +
+		styleReset
+	lines := make([]string, len(tasks))
+	for i, t := range tasks {
+		t.Title = runewidth.Truncate(t.Title, titleWidth, "…")
+		title, due, status, priority := util.FormatTask(t)
+		lines[i] = " " + gutter(t) + row(strconv.Itoa(t.ID), title, due, status, priority)
+	}
+	return header, lines
+}
+
+// highlight shows line in reverse video across the full width, as the line under the cursor
+func highlight(line string, width int) string {
+	return styleReverse + strings.ReplaceAll(pad(line, width), styleReset, styleReset+styleReverse) + styleReset
+}
+
+// detailPane returns the lines of the detail pane, showing every field of the selected task
+func (a *app) detailPane(width int) []string {
+	lines := []string{strings.Repeat("─", width)}
+
+	t, ok := a.selected()
+	if !ok {
+		lines = append(lines, " No task selected")
+	} else {
+		_, due, status, priority := util.FormatTask(t)
+		completed := t.CompleteDate.String
+		if completed == "" {
+			completed = "-"
+		}
+		tags := strings.Join(t.Tags, ", ")
+		if tags == "" {
+			tags = "none"
+		}
+
+		lines = append(lines,
+			fmt.Sprintf(" ID:        %d (handle %s)", t.ID, t.Handle),
+			" Title:     "+runewidth.Truncate(t.Title, max(0, width-12), "…"),
+			" Status:    "+status,
+			" Due:       "+due,
+			" Priority:  "+priority,
+			" Tags:      "+tags,
+			" Created:   "+formatTime(t.CreatedAt),
+			" Updated:   "+formatTime(t.UpdatedAt),
+			" Completed: "+completed,
+		)
+	}
+
+	for len(lines) < detailHeight {
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+// statusLine shows the prompt being answered, the result of the last action, or the keys that can be pressed
+func (a *app) statusLine(width int) string {
+	var line string
+	switch {
+	case a.mode == modeFilter:
+		return typingLine(" Filter: "+a.filter+"█", width)
+	case a.mode == modePrompt:
+		return typingLine(" "+a.prompt+string(a.input)+"█", width)
+	case a.mode == modeConfirm:
+		line = " " + a.prompt
+	case a.message != "":
+		line = " " + a.message
+	case a.help:
+		line = " " + helpLong
+	default:
+		line = " " + helpShort
+	}
+	return runewidth.Truncate(line, width, "…")
+}
+
+// typingLine fits a line being typed into width, keeping the end where the typing happens
+func typingLine(line string, width int) string {
+	runes := []rune(line)
+	for len(runes) > 1 && runewidth.StringWidth(string(runes)) > width-1 {
+		runes = runes[1:]
+	}
+	if len(runes) < len([]rune(line)) {
+		return "…" + string(runes)
+	}
+	return line
+}
+
+// formatTime returns t in local time to the minute, or "unknown" if it is the zero time
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "unknown"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+// visibleWidth returns the number of columns s takes on screen, ignoring colour codes
+func visibleWidth(s string) int {
+	return runewidth.StringWidth(ansiStyle.ReplaceAllString(s, ""))
+}
+
+// pad adds spaces to s so it takes up width columns on screen
+func pad(s string, width int) string {
+	return s + strings.Repeat(" ", max(0, width-visibleWidth(s)))
+}
@@ -0,0 +1,166 @@
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// ErrNotTerminal is returned when the interface is opened without a terminal for both input and output
+var ErrNotTerminal = errors.New("not a terminal")
+
+// key is a key press, either a printable rune or a named special key such as "up" or "enter"
+type key struct {
+	r    rune
+	name string
+}
+
+// terminal controls the user's terminal while the interface is open
+type terminal struct {
+	in    *bufio.Reader
+	out   *bufio.Writer
+	fd    int
+	state *term.State
+}
+
+// IsTerminal reports whether stdin and stdout are both terminals, as the interface and Pick need
+func IsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// openTerminal switches the terminal to raw mode and the alternate screen, hiding the cursor.
+// close must be called to restore the terminal
+func openTerminal() (*terminal, error) {
+	if !IsTerminal() {
+		return nil, ErrNotTerminal
+	}
+
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &terminal{in: bufio.NewReader(os.Stdin), out: bufio.NewWriter(os.Stdout), fd: fd, state: state}
+	_, _ = t.out.WriteString("\x1b[?1049h\x1b[?25l")
+	return t, t.out.Flush()
+}
+
+// close leaves the alternate screen, shows the cursor and restores the terminal mode
+func (t *terminal) close() error {
+	_, _ = t.out.WriteString("\x1b[?25h\x1b[?1049l")
+	_ = t.out.Flush()
+	return term.Restore(t.fd, t.state)
+}
+
+// size returns the width and height of the terminal, falling back to 80x24 if it is unknown
+func (t *terminal) size() (int, int) {
+	w, h, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || w <= 0 || h <= 0 {
+		return 80, 24
+	}
+	return w, h
+}
+
+// draw replaces the screen with lines, drawn from the top left
+func (t *terminal) draw(lines []string) error {
+
+	// clear the rest of each line, and anything below the last line
+	var b strings.Builder
+	b.WriteString("\x1b[H")
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+		b.WriteString(line + "\x1b[K")
+	}
+	b.WriteString("\x1b[J")
+
+	if _, err := t.out.WriteString(b.String()); err != nil {
+		return err
+	}
+	return t.out.Flush()
+}
+
+// readKey waits for the next key press.
+// escape sequences sent by arrow and paging keys are read as a single named key
+func (t *terminal) readKey() (key, error) {
+	r, _, err := t.in.ReadRune()
+	if err != nil {
+		return key{}, err
+	}
+
+	switch r {
+	case '\r', '\n':
+		return key{name: "enter"}, nil
+	case '\t':
+		return key{name: "tab"}, nil
+	case 0x7f, 0x08:
+		return key{name: "backspace"}, nil
+	case 0x03:
+		return key{name: "ctrl-c"}, nil
+	case 0x15:
+		return key{name: "ctrl-u"}, nil
+	case 0x1b:
+		return t.readEscape()
+	}
+
+	// ignore other control characters
+	if r < 0x20 {
+		return key{}, nil
+	}
+	return key{r: r}, nil
+}
+
+// readEscape reads the rest of an escape sequence, a lone escape is the escape key
+func (t *terminal) readEscape() (key, error) {
+	if t.in.Buffered() == 0 {
+		return key{name: "esc"}, nil
+	}
+
+	b, err := t.in.ReadByte()
+	if err != nil {
+		return key{}, err
+	}
+	if b != '[' && b != 'O' {
+		return key{name: "esc"}, nil
+	}
+
+	// read parameters up to the final byte, e.g. the 5 of "5~"
+	var params []byte
+	for {
+		b, err = t.in.ReadByte()
+		if err != nil {
+			return key{}, err
+		}
+		if b < '0' || b > ';' {
+			break
+		}
+		params = append(params, b)
+	}
+
+	switch {
+	case b == 'A':
+		return key{name: "up"}, nil
+	case b == 'B':
+		return key{name: "down"}, nil
+	case b == 'C':
+		return key{name: "right"}, nil
+	case b == 'D':
+		return key{name: "left"}, nil
+	case b == 'H', b == '~' && (string(params) == "1" || string(params) == "7"):
+		return key{name: "home"}, nil
+	case b == 'F', b == '~' && (string(params) == "4" || string(params) == "8"):
+		return key{name: "end"}, nil
+	case b == '~' && string(params) == "5":
+		return key{name: "pgup"}, nil
+	case b == '~' && string(params) == "6":
+		return key{name: "pgdown"}, nil
+	}
+
+	// unknown sequences are ignored
+	return key{}, nil
+}
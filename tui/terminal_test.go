@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// newTerminal returns a terminal reading input and writing to out, without touching the real terminal
+func newTerminal(input string, out *bytes.Buffer) *terminal {
+	return &terminal{in: bufio.NewReader(strings.NewReader(input)), out: bufio.NewWriter(out)}
+}
+
+func TestReadKey(t *testing.T) {
+	tests := []struct {
+		input string
+		want  key
+	}{
+		{"a", key{r: 'a'}},
+		{"é", key{r: 'é'}},
+		{" ", key{r: ' '}},
+		{"\r", key{name: "enter"}},
+		{"\n", key{name: "enter"}},
+		{"\t", key{name: "tab"}},
+		{"\x7f", key{name: "backspace"}},
+		{"\x08", key{name: "backspace"}},
+		{"\x03", key{name: "ctrl-c"}},
+		{"\x15", key{name: "ctrl-u"}},
+		{"\x01", key{}},
+		{"\x1b", key{name: "esc"}},
+		{"\x1bx", key{name: "esc"}},
+		{"\x1b[A", key{name: "up"}},
+		{"\x1b[B", key{name: "down"}},
+		{"\x1bOC", key{name: "right"}},
+		{"\x1b[D", key{name: "left"}},
+		{"\x1b[H", key{name: "home"}},
+		{"\x1b[1~", key{name: "home"}},
+		{"\x1b[F", key{name: "end"}},
+		{"\x1b[4~", key{name: "end"}},
+		{"\x1b[5~", key{name: "pgup"}},
+		{"\x1b[6~", key{name: "pgdown"}},
+		{"\x1b[1;5A", key{name: "up"}},
+		{"\x1b[2~", key{}},
+	}
+	for _, tt := range tests {
+		got, err := newTerminal(tt.input, nil).readKey()
+		if err != nil {
+			t.Errorf("readKey(%q) error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("readKey(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+
+	// keys are read one after another
+	term := newTerminal("j\x1b[Bq", nil)
+	for _, want := range []key{{r: 'j'}, {name: "down"}, {r: 'q'}} {
+		if got, err := term.readKey(); err != nil || got != want {
+			t.Errorf("readKey() = %+v, %v; want %+v", got, err, want)
+		}
+	}
+	if _, err := term.readKey(); err == nil {
+		t.Error("expected an error at the end of the input")
+	}
+}
+
+func TestDraw(t *testing.T) {
+	var out bytes.Buffer
+	if err := newTerminal("", &out).draw([]string{"first", "second"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := out.String(), "\x1b[Hfirst\x1b[K\r\nsecond\x1b[K\x1b[J"; got != want {
+		t.Errorf("draw wrote %q, want %q", got, want)
+	}
+}
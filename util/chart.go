@@ -0,0 +1,110 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BarChart draws a horizontal bar for each value, next to its label and followed by the value.
+// bars are scaled so the largest value fills width, and are coloured with the terminal colour profile
+func BarChart(labels []string, values []int, width int) []string {
+	labelWidth, most := 0, 0
+	for i, v := range values {
+		labelWidth = max(labelWidth, len(labels[i]))
+		most = max(most, v)
+	}
+
+	lines := make([]string, len(values))
+	for i, v := range values {
+		bar := ""
+		if most > 0 {
+			bar = strings.Repeat("█", v*width/most)
+		}
+
+		// show a sliver for small values, so they can be told apart from zero
+		if bar == "" && v > 0 {
+			bar = "▏"
+		}
+		lines[i] = fmt.Sprintf("%-*s │%s %d", labelWidth, labels[i], colorise(bar, green), v)
+	}
+
+	return lines
+}
+
+// LineChart draws values from left to right as a line, height rows tall, with the y-axis labelled by value.
+// charts are at least 2 rows tall. the first and last labels are shown under the x-axis
+func LineChart(labels []string, values []int, height int) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	height = max(height, 2)
+
+	most := 1
+	for _, v := range values {
+		most = max(most, v)
+	}
+
+	// use two columns per value while the chart is narrow, so the line is easier to follow
+	cellWidth := 1
+	if len(values) <= 40 {
+		cellWidth = 2
+	}
+
+	// plot each value, joining it to the last with a vertical line
+	row := func(v int) int { return (v*(height-1) + most/2) / most }
+	grid := make([][]rune, height)
+	for r := range grid {
+		grid[r] = []rune(strings.Repeat(" ", len(values)))
+	}
+	for i, v := range values {
+		r := row(v)
+		grid[r][i] = '●'
+		if i == 0 {
+			continue
+		}
+		prev := row(values[i-1])
+		for k := min(r, prev) + 1; k < max(r, prev); k++ {
+			grid[k][i] = '│'
+		}
+	}
+
+	// label the top, middle and bottom of the y-axis, on the rows where those values are plotted.
+	// the middle label is left out if it would share a row with the top or bottom
+	axisWidth := len(strconv.Itoa(most))
+	axisLabels := map[int]string{}
+	for _, v := range []int{most / 2, 0, most} {
+		axisLabels[row(v)] = strconv.Itoa(v)
+	}
+	var lines []string
+	for r := height - 1; r >= 0; r-- {
+		label, tick := "", "│"
+		if l, ok := axisLabels[r]; ok {
+			label, tick = l, "┤"
+		}
+
+		var b strings.Builder
+		for _, c := range grid[r] {
+			cell := string(c) + strings.Repeat(" ", cellWidth-1)
+			if c != ' ' {
+				cell = colorise(cell, brightBlue)
+			}
+			b.WriteString(cell)
+		}
+		lines = append(lines, fmt.Sprintf("%*s %s%s", axisWidth, label, tick, strings.TrimRight(b.String(), " ")))
+	}
+
+	// draw the x-axis, with the first and last labels at each end
+	indent := strings.Repeat(" ", axisWidth+1)
+	axisLength := len(values) * cellWidth
+	lines = append(lines, indent+"└"+strings.Repeat("─", axisLength))
+	first, last := labels[0], labels[len(labels)-1]
+	gap := axisLength + 1 - len(first) - len(last)
+	if len(values) == 1 {
+		lines = append(lines, indent+first)
+	} else {
+		lines = append(lines, indent+first+strings.Repeat(" ", max(gap, 1))+last)
+	}
+
+	return lines
+}
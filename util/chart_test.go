@@ -0,0 +1,93 @@
+package util
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestBarChart(t *testing.T) {
+	plain(t)
+	got := BarChart([]string{"Mon", "Tuesday", "Wed"}, []int{2, 4, 0}, 8)
+	want := []string{
+		"Mon     │████ 2",
+		"Tuesday │████████ 4",
+		"Wed     │ 0",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("BarChart() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// a small value still shows a bar
+	if got := BarChart([]string{"a", "b"}, []int{1, 100}, 10); !strings.Contains(got[0], "▏") {
+		t.Errorf("small value has no bar: %q", got[0])
+	}
+}
+
+func TestLineChart(t *testing.T) {
+	plain(t)
+	got := LineChart([]string{"first", "x", "last"}, []int{0, 4, 8}, 10)
+	want := []string{
+		"8 ┤    ●",
+		"  │    │",
+		"  │    │",
+		"  │    │",
+		"4 ┤  ●",
+		"  │  │",
+		"  │  │",
+		"  │  │",
+		"  │  │",
+		"0 ┤●",
+		"  └──────",
+		"  first last",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("LineChart() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestLineChartLabels(t *testing.T) {
+	plain(t)
+
+	// each y-axis label is on the row where that value is plotted, and the top and bottom are always labelled
+	for most := 1; most <= 30; most++ {
+		for _, height := range []int{2, 3, 5, 10} {
+			values := []int{0, most / 2, most}
+			lines := LineChart([]string{"a", "b", "c"}, values, height)
+			chart := strings.Join(lines, "\n")
+
+			labelled := map[int]bool{}
+			for _, line := range lines[:height] {
+				axis, plot, ok := strings.Cut(line, "┤")
+				if !ok {
+					continue
+				}
+				v, err := strconv.Atoi(strings.TrimSpace(axis))
+				if err != nil {
+					t.Fatalf("invalid label %q:\n%s", axis, chart)
+				}
+				labelled[v] = true
+
+				i := slices.Index(values, v)
+				if cells := []rune(plot); i < 0 || len(cells) <= 2*i || cells[2*i] != '●' {
+					t.Errorf("most %d, height %d: %d is not plotted on its label's row:\n%s", most, height, v, chart)
+				}
+			}
+			if !labelled[0] || !labelled[most] {
+				t.Errorf("most %d, height %d: top or bottom is not labelled:\n%s", most, height, chart)
+			}
+		}
+	}
+}
+
+func TestLineChartSmallHeight(t *testing.T) {
+	for _, height := range []int{-1, 0, 1} {
+		if got := LineChart([]string{"a", "b"}, []int{1, 3}, height); len(got) != 4 {
+			t.Errorf("LineChart with height %d has %d lines, want 4", height, len(got))
+		}
+	}
+	if got := LineChart(nil, nil, 10); got != nil {
+		t.Errorf("LineChart with no values = %q, want nil", got)
+	}
+}
@@ -3,20 +3,19 @@ package util
 import (
 	"bufio"
 	"fmt"
-	"os"
+	"io"
 	"strings"
 )
 
-// ConfirmAction prompts the user for a message and waits for yes/no confirmation
-// it reads user input to stdin and returns true if the user responds with "y" or "yes"
-// any other input is treated as a negative response
-func ConfirmAction(prompt string) bool {
+// ConfirmAction writes a prompt to w and waits for a yes/no answer from r.
+// it returns true if the user responds with "y" or "yes", any other input is treated as a negative response
+func ConfirmAction(r io.Reader, w io.Writer, prompt string) bool {
 
-	// create new buffered reader to read input from terminal
-	reader := bufio.NewReader(os.Stdin)
+	// create new buffered reader to read the answer
+	reader := bufio.NewReader(r)
 
 	// print the prompt message with [y/N] suffix
-	fmt.Printf("%s [y/N]: ", prompt)
+	_, _ = fmt.Fprintf(w, "%s [y/N]: ", prompt)
 
 	// read a line of input from the user
 	input, _ := reader.ReadString('\n')
@@ -0,0 +1,35 @@
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfirmAction(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{"y", "y\n", true},
+		{"yes", "yes\n", true},
+		{"upper case", "YES\n", true},
+		{"whitespace", "  y \t\n", true},
+		{"no newline", "y", true},
+		{"n", "n\n", false},
+		{"no", "no\n", false},
+		{"empty line", "\n", false},
+		{"eof", "", false},
+		{"other", "yep\n", false},
+		{"only the first line", "n\ny\n", false},
+	}
+	for _, tt := range tests {
+		var out strings.Builder
+		if got := ConfirmAction(strings.NewReader(tt.input), &out, "Delete?"); got != tt.want {
+			t.Errorf("%s: ConfirmAction(%q) = %v, want %v", tt.name, tt.input, got, tt.want)
+		}
+		if out.String() != "Delete? [y/N]: " {
+			t.Errorf("%s: prompt = %q", tt.name, out.String())
+		}
+	}
+}
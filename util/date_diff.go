@@ -9,12 +9,8 @@ import (
 // it returns a human-readable string showing the number of days between them
 func dateDiff(a, b time.Time) string {
 
-	// ignore time of day by truncating both to midnight
-	a = a.Truncate(24 * time.Hour)
-	b = b.Truncate(24 * time.Hour)
-
-	// calculate the difference in hours, then convert to days
-	diff := int(b.Sub(a).Hours() / 24)
+	// count calendar days, ignoring the time of day
+	diff := DaysBetween(a, b)
 
 	// take the absolute value to ensure positive day count
 	if diff < 0 {
@@ -28,3 +24,17 @@ func dateDiff(a, b time.Time) string {
 
 	return fmt.Sprintf("%d days", diff)
 }
+
+// Today returns the start of the current day, in local time
+func Today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// DaysBetween returns the number of calendar days from a to b, negative if b is before a. only the dates count,
+// so a day when the clocks change is still one day
+func DaysBetween(a, b time.Time) int {
+	a = time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	b = time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
@@ -0,0 +1,78 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateDiff(t *testing.T) {
+	auckland := time.FixedZone("NZDT", 13*60*60)
+	tests := []struct {
+		a, b time.Time
+		want string
+	}{
+		{time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 10, 23, 0, 0, 0, time.UTC), "0 days"},
+		{time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC), "1 day"},
+		{time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), "10 days"},
+
+		// dates are compared as written in their own time zone, 00:30 in Auckland is still the 10th there
+		{time.Date(2025, 3, 10, 0, 30, 0, 0, auckland), time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC), "1 day"},
+	}
+	for _, tt := range tests {
+		if got := dateDiff(tt.a, tt.b); got != tt.want {
+			t.Errorf("dateDiff(%v, %v) = %q, want %q", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDeadline(t *testing.T) {
+	// at any moment, at least one of these zones has a different date from UTC
+	defer func(local *time.Location) { time.Local = local }(time.Local)
+	for _, zone := range []*time.Location{time.FixedZone("UTC+14", 14*60*60), time.FixedZone("UTC-12", -12*60*60)} {
+		time.Local = zone
+		today := Today()
+		day := func(offset int) string { return today.AddDate(0, 0, offset).Format("2006-01-02") }
+
+		tests := []struct {
+			due  string
+			want string
+		}{
+			{"", "None"},
+			{"soon", "Invalid date"},
+			{day(-3), "Overdue: 3 days"},
+			{day(-1), "Overdue: 1 day"},
+			{day(0), "Today"},
+			{day(1), "Tomorrow"},
+			{day(6), today.AddDate(0, 0, 6).Weekday().String()},
+			{day(7), day(7)},
+		}
+		for _, tt := range tests {
+			if got := FormatDeadline(tt.due); got != tt.want {
+				t.Errorf("%s: FormatDeadline(%q) = %q, want %q", zone, tt.due, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestDaysBetween(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone data not available")
+	}
+	date := func(day, hour int) time.Time { return time.Date(2025, 3, day, hour, 0, 0, 0, location) }
+
+	tests := []struct {
+		a, b time.Time
+		want int
+	}{
+		{date(3, 0), date(3, 23), 0},
+		{date(3, 23), date(4, 0), 1},
+		{date(3, 0), date(10, 0), 7}, // across the change to daylight saving time, an hour short
+		{date(10, 0), date(3, 0), -7},
+	}
+	for _, tt := range tests {
+		if got := DaysBetween(tt.a, tt.b); got != tt.want {
+			t.Errorf("DaysBetween(%v, %v) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DueKeywords lists the relative dates accepted by ParseDueRange, resolved each time they are used
+var DueKeywords = []string{"overdue", "today", "tomorrow", "this-week", "next-week", "this-month"}
+
+// ParseDueRange parses a due date filter into the first and last dates it covers, in YYYY-MM-DD format.
+// an empty from or to leaves that end of the range open. the filter can be:
+//   - a date, e.g. 2025-07-01
+//   - one of DueKeywords, where weeks run from Monday to Sunday and overdue means before today
+//   - a period such as 3d, 2w or 1m, meaning due from today until the end of the period, as in AddPeriod
+func ParseDueRange(spec string) (from, to string, err error) {
+	const layout = "2006-01-02"
+
+	// get today's date at midnight, in local time, and the Monday of this week
+	today := Today()
+	monday := StartOfWeek(today)
+
+	switch spec {
+	case "overdue":
+		return "", today.AddDate(0, 0, -1).Format(layout), nil
+	case "today":
+		return today.Format(layout), today.Format(layout), nil
+	case "tomorrow":
+		tomorrow := today.AddDate(0, 0, 1)
+		return tomorrow.Format(layout), tomorrow.Format(layout), nil
+	case "this-week":
+		return today.Format(layout), monday.AddDate(0, 0, 6).Format(layout), nil
+	case "next-week":
+		return monday.AddDate(0, 0, 7).Format(layout), monday.AddDate(0, 0, 13).Format(layout), nil
+	case "this-month":
+		end := time.Date(today.Year(), today.Month()+1, 0, 0, 0, 0, 0, time.Local)
+		return today.Format(layout), end.Format(layout), nil
+	}
+
+	// a single date
+	if _, err := time.Parse(layout, spec); err == nil {
+		return spec, spec, nil
+	}
+
+	// a period from today, optionally written with a leading +
+	end, err := AddPeriod(today, strings.TrimPrefix(spec, "+"))
+	if err != nil {
+		return "", "", fmt.Errorf("invalid due date %q; use a date (YYYY-MM-DD), a period such as 2w, or one of %s",
+			spec, strings.Join(DueKeywords, ", "))
+	}
+	return today.Format(layout), end.Format(layout), nil
+}
@@ -0,0 +1,41 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDueRange(t *testing.T) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	day := func(d time.Time) string { return d.Format("2006-01-02") }
+	monday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+
+	tests := []struct {
+		spec     string
+		from, to string
+	}{
+		{"2025-07-01", "2025-07-01", "2025-07-01"},
+		{"overdue", "", day(today.AddDate(0, 0, -1))},
+		{"today", day(today), day(today)},
+		{"tomorrow", day(today.AddDate(0, 0, 1)), day(today.AddDate(0, 0, 1))},
+		{"this-week", day(today), day(monday.AddDate(0, 0, 6))},
+		{"next-week", day(monday.AddDate(0, 0, 7)), day(monday.AddDate(0, 0, 13))},
+		{"2w", day(today), day(today.AddDate(0, 0, 14))},
+		{"+1m", day(today), day(addMonths(today, 1))},
+	}
+	for _, tt := range tests {
+		from, to, err := ParseDueRange(tt.spec)
+		if err != nil {
+			t.Errorf("ParseDueRange(%q) error: %v", tt.spec, err)
+			continue
+		}
+		if from != tt.from || to != tt.to {
+			t.Errorf("ParseDueRange(%q) = %q, %q, want %q, %q", tt.spec, from, to, tt.from, tt.to)
+		}
+	}
+
+	if _, _, err := ParseDueRange("soon"); err == nil {
+		t.Error("ParseDueRange(soon) did not return an error")
+	}
+}
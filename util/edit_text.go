@@ -0,0 +1,56 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// EditText opens text in the user's editor and returns the text as it was saved.
+// the editor is taken from $VISUAL, then $EDITOR, falling back to vi, and may include arguments such as "code --wait".
+// pattern names the temporary file, as for os.CreateTemp, so a suffix such as ".txt" can enable syntax highlighting
+func EditText(text, pattern string) (string, error) {
+
+	// choose the editor
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	fields := strings.Fields(editor)
+
+	// write the text to a temporary file, removed once the editor closes
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	if _, err := f.WriteString(text); err != nil {
+		_ = f.Close()
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	// run the editor attached to the terminal, and wait for it to close
+	editCmd := exec.Command(fields[0], append(fields[1:], f.Name())...)
+	editCmd.Stdin = os.Stdin
+	editCmd.Stdout = os.Stdout
+	editCmd.Stderr = os.Stderr
+	if err := editCmd.Run(); err != nil {
+		return "", fmt.Errorf("editor %q failed: %w", editor, err)
+	}
+
+	// read back the saved text
+	edited, err := os.ReadFile(f.Name())
+	if err != nil {
+		return "", fmt.Errorf("failed to read edited file: %w", err)
+	}
+
+	return string(edited), nil
+}
@@ -0,0 +1,60 @@
+package util
+
+import (
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+const (
+	// highlightOn and highlightOff turn bold and underline on and off without resetting the colour,
+	// so highlights can sit inside text coloured by colorise
+	highlightOn  = "\x1b[1;4m"
+	highlightOff = "\x1b[22;24m"
+)
+
+// highlightTerms marks each case-insensitive occurrence of the terms in s with bold and underline.
+// nothing is marked when the terminal does not support styling
+func highlightTerms(s string, terms []string) string {
+	if len(terms) == 0 || p == termenv.Ascii {
+		return s
+	}
+
+	// lower-casing can change byte lengths for some characters, so give up rather than split a character
+	lower := strings.ToLower(s)
+	if len(lower) != len(s) {
+		return s
+	}
+
+	// mark the bytes covered by any term
+	marked := make([]bool, len(s))
+	for _, term := range terms {
+		term = strings.ToLower(term)
+		if term == "" {
+			continue
+		}
+		for i := 0; i+len(term) <= len(lower); {
+			j := strings.Index(lower[i:], term)
+			if j < 0 {
+				break
+			}
+			for k := i + j; k < i+j+len(term); k++ {
+				marked[k] = true
+			}
+			i += j + len(term)
+		}
+	}
+
+	// rebuild the string, wrapping each marked run
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if marked[i] && (i == 0 || !marked[i-1]) {
+			b.WriteString(highlightOn)
+		}
+		b.WriteByte(s[i])
+		if marked[i] && (i == len(s)-1 || !marked[i+1]) {
+			b.WriteString(highlightOff)
+		}
+	}
+	return b.String()
+}
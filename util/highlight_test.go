@@ -0,0 +1,40 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestHighlightTerms(t *testing.T) {
+	old := p
+	p = termenv.ANSI
+	t.Cleanup(func() { p = old })
+
+	on, off := highlightOn, highlightOff
+	tests := []struct {
+		s     string
+		terms []string
+		want  string
+	}{
+		{"Deploy to staging", []string{"stag"}, "Deploy to " + on + "stag" + off + "ing"},
+		{"Deploy to staging", []string{"DEPLOY"}, on + "Deploy" + off + " to staging"},
+		{"aaa", []string{"a"}, on + "aaa" + off},
+		{"deploy to staging", []string{"deploy", "to"}, on + "deploy" + off + " " + on + "to" + off + " staging"},
+		{"overlapping", []string{"overl", "lapping"}, on + "overlapping" + off},
+		{"no match", []string{"xyz"}, "no match"},
+		{"no terms", nil, "no terms"},
+		{"empty term", []string{""}, "empty term"},
+	}
+	for _, tt := range tests {
+		if got := highlightTerms(tt.s, tt.terms); got != tt.want {
+			t.Errorf("highlightTerms(%q, %q) = %q, want %q", tt.s, tt.terms, got, tt.want)
+		}
+	}
+
+	// nothing is marked without styling
+	p = termenv.Ascii
+	if got := highlightTerms("Deploy to staging", []string{"stag"}); got != "Deploy to staging" {
+		t.Errorf("highlightTerms without styling = %q", got)
+	}
+}
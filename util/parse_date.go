@@ -0,0 +1,53 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateKeywords lists the relative dates accepted by ParseDate, in addition to weekday names
+var DateKeywords = []string{"today", "tomorrow", "+1d", "+1w", "+1m"}
+
+// ParseDate parses a due date and returns it in YYYY-MM-DD format. the date can be:
+//   - a date, e.g. 2025-07-01
+//   - today or tomorrow
+//   - a weekday, e.g. friday, meaning the next one after today
+//   - a period from today, e.g. +3d, +2w or +1m, where months are calendar months as in AddPeriod
+func ParseDate(spec string) (string, error) {
+	const layout = "2006-01-02"
+
+	// a date is returned as it is
+	if _, err := time.Parse(layout, spec); err == nil {
+		return spec, nil
+	}
+
+	// get today's date at midnight, in local time
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	spec = strings.ToLower(spec)
+	switch spec {
+	case "today":
+		return today.Format(layout), nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1).Format(layout), nil
+	}
+
+	// the next weekday with the given name, a week today if it is today
+	for d := 1; d <= 7; d++ {
+		day := today.AddDate(0, 0, d)
+		if strings.ToLower(day.Weekday().String()) == spec {
+			return day.Format(layout), nil
+		}
+	}
+
+	// a period from today
+	if strings.HasPrefix(spec, "+") {
+		if day, err := AddPeriod(today, spec[1:]); err == nil {
+			return day.Format(layout), nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid date %q; use YYYY-MM-DD, today, tomorrow, a weekday, or a period such as +1w", spec)
+}
@@ -0,0 +1,55 @@
+package util
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	day := func(d time.Time) string { return d.Format("2006-01-02") }
+
+	// the next friday after today, a week today if today is friday
+	friday := today.AddDate(0, 0, 1)
+	for friday.Weekday() != time.Friday {
+		friday = friday.AddDate(0, 0, 1)
+	}
+
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{"2025-07-01", "2025-07-01"},
+		{"today", day(today)},
+		{"Tomorrow", day(today.AddDate(0, 0, 1))},
+		{"friday", day(friday)},
+		{"FRIDAY", day(friday)},
+		{"+0d", day(today)},
+		{"+3d", day(today.AddDate(0, 0, 3))},
+		{"+2w", day(today.AddDate(0, 0, 14))},
+		{"+1m", day(addMonths(today, 1))},
+		{"+1y", day(addMonths(today, 12))},
+	}
+	for _, tt := range tests {
+		got, err := ParseDate(tt.spec)
+		if err != nil {
+			t.Errorf("ParseDate(%q) error: %v", tt.spec, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseDate(%q) = %s, want %s", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestParseDateErrors(t *testing.T) {
+	for _, spec := range []string{"", "2025-13-01", "01-07-2025", "someday", "+", "+1", "+-1d", "+1h", "3d"} {
+		if got, err := ParseDate(spec); err == nil {
+			t.Errorf("ParseDate(%q) = %s, want an error", spec, got)
+		} else if !strings.Contains(err.Error(), "invalid date") {
+			t.Errorf("ParseDate(%q) error = %v", spec, err)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ParsePeriod parses a period of whole days, weeks, months or years such as "30d", "2w", "6m" or "1y".
+// months are treated as 30 days and years as 365 days, use AddPeriod to move a date by calendar months.
+func ParsePeriod(period string) (time.Duration, error) {
+	n, unit, err := splitPeriod(period)
+	if err != nil {
+		return 0, err
+	}
+
+	// convert the unit into a number of days
+	var days int
+	switch unit {
+	case 'd':
+		days = n
+	case 'w':
+		days = n * 7
+	case 'm':
+		days = n * 30
+	case 'y':
+		days = n * 365
+	}
+
+	return time.Duration(days) * 24 * time.Hour, nil
+}
+
+// AddPeriod returns day moved forward by a period such as "3d", "2w", "6m" or "1y".
+// months and years are calendar months and years, ending on the last day of the month when it is shorter,
+// so 2025-01-31 plus 1m is 2025-02-28
+func AddPeriod(day time.Time, period string) (time.Time, error) {
+	n, unit, err := splitPeriod(period)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	switch unit {
+	case 'd':
+		return day.AddDate(0, 0, n), nil
+	case 'w':
+		return day.AddDate(0, 0, n*7), nil
+	case 'm':
+		return addMonths(day, n), nil
+	default:
+		return addMonths(day, n*12), nil
+	}
+}
+
+// addMonths returns day moved forward by n calendar months, keeping the day of the month where it exists
+func addMonths(day time.Time, n int) time.Time {
+	first := time.Date(day.Year(), day.Month()+time.Month(n), 1, 0, 0, 0, 0, day.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	return time.Date(first.Year(), first.Month(), min(day.Day(), last),
+		day.Hour(), day.Minute(), day.Second(), day.Nanosecond(), day.Location())
+}
+
+// splitPeriod splits a period into its number and unit, which is one of d, w, m or y
+func splitPeriod(period string) (int, byte, error) {
+	errInvalid := fmt.Errorf("invalid period %q; use a number followed by d, w, m or y, e.g. 30d", period)
+
+	// a period needs at least one digit and a unit
+	if len(period) < 2 {
+		return 0, 0, errInvalid
+	}
+
+	// split into the number and unit
+	n, err := strconv.Atoi(period[:len(period)-1])
+	if err != nil || n < 0 {
+		return 0, 0, errInvalid
+	}
+
+	unit := period[len(period)-1]
+	switch unit {
+	case 'd', 'w', 'm', 'y':
+		return n, unit, nil
+	default:
+		return 0, 0, errInvalid
+	}
+}
@@ -0,0 +1,68 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePeriod(t *testing.T) {
+	tests := []struct {
+		period string
+		days   int
+	}{
+		{"0d", 0},
+		{"30d", 30},
+		{"2w", 14},
+		{"6m", 180},
+		{"1y", 365},
+	}
+	for _, tt := range tests {
+		got, err := ParsePeriod(tt.period)
+		if err != nil {
+			t.Errorf("ParsePeriod(%q) error: %v", tt.period, err)
+			continue
+		}
+		if want := time.Duration(tt.days) * 24 * time.Hour; got != want {
+			t.Errorf("ParsePeriod(%q) = %v, want %v", tt.period, got, want)
+		}
+	}
+
+	for _, period := range []string{"", "d", "3", "-1d", "1.5d", "3h", "w2"} {
+		if _, err := ParsePeriod(period); err == nil {
+			t.Errorf("ParsePeriod(%q) did not return an error", period)
+		}
+	}
+}
+
+func TestAddPeriod(t *testing.T) {
+	tests := []struct {
+		day    string
+		period string
+		want   string
+	}{
+		{"2025-01-10", "3d", "2025-01-13"},
+		{"2025-01-10", "2w", "2025-01-24"},
+		{"2025-01-10", "1m", "2025-02-10"},
+		{"2025-01-31", "1m", "2025-02-28"},
+		{"2024-01-31", "1m", "2024-02-29"},
+		{"2025-03-31", "1m", "2025-04-30"},
+		{"2025-11-15", "3m", "2026-02-15"},
+		{"2024-02-29", "1y", "2025-02-28"},
+		{"2025-10-20", "1m", "2025-11-20"},
+	}
+	for _, tt := range tests {
+		day, _ := time.ParseInLocation("2006-01-02", tt.day, time.Local)
+		got, err := AddPeriod(day, tt.period)
+		if err != nil {
+			t.Errorf("AddPeriod(%s, %q) error: %v", tt.day, tt.period, err)
+			continue
+		}
+		if got.Format("2006-01-02") != tt.want {
+			t.Errorf("AddPeriod(%s, %q) = %s, want %s", tt.day, tt.period, got.Format("2006-01-02"), tt.want)
+		}
+	}
+
+	if _, err := AddPeriod(time.Now(), "1q"); err == nil {
+		t.Error("AddPeriod with an unknown unit did not return an error")
+	}
+}
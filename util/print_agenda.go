@@ -0,0 +1,77 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/tm-craggs/tidytask/task"
+)
+
+// AgendaSection is a heading in the agenda, with the tasks listed under it
+type AgendaSection struct {
+	Title string
+	Tasks []task.Task
+}
+
+// PrintAgenda prints each section that has tasks as a heading followed by one line per task.
+// high priority tasks are marked with ! and highlighted, and overdue tasks are coloured red
+func PrintAgenda(sections []AgendaSection) {
+
+	// line up the columns across every section
+	idWidth, titleWidth := 0, 0
+	for _, s := range sections {
+		for _, t := range s.Tasks {
+			idWidth = max(idWidth, len(strconv.Itoa(t.ID)))
+			titleWidth = max(titleWidth, runewidth.StringWidth(t.Title))
+		}
+	}
+
+	first := true
+	for _, s := range sections {
+		if len(s.Tasks) == 0 {
+			continue
+		}
+		if !first {
+			fmt.Println()
+		}
+		first = false
+
+		fmt.Printf("%s (%d)\n", s.Title, len(s.Tasks))
+		for _, t := range s.Tasks {
+			fmt.Println(formatAgendaLine(t, idWidth, titleWidth))
+		}
+	}
+}
+
+// formatAgendaLine returns a task as a line of the agenda, with its ID, title, due date, status and tags
+func formatAgendaLine(t task.Task, idWidth, titleWidth int) string {
+	marker, title := " ", runewidth.FillRight(t.Title, titleWidth)
+	if t.Priority {
+		marker, title = colorise("!", brightBlue), colorise(title, brightBlue)
+	}
+
+	// colour the due date as in PrintTasks
+	due := FormatDeadline(t.Due)
+	switch {
+	case strings.HasPrefix(due, "Overdue"):
+		due = colorise(due, red)
+	case due == "Today":
+		due = colorise(due, orange)
+	case due == "Tomorrow":
+		due = colorise(due, yellow)
+	}
+
+	line := fmt.Sprintf("  %s %*d  %s  %s", marker, idWidth, t.ID, title, due)
+
+	// show the status once work has started, and any tags
+	if t.Status != task.StatusTodo {
+		line += "  " + formatStatus(t.Status)
+	}
+	for _, tag := range t.Tags {
+		line += "  " + colorise("#"+tag, grey)
+	}
+
+	return line
+}
@@ -0,0 +1,27 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/tm-craggs/tidytask/task"
+)
+
+func TestFormatAgendaLine(t *testing.T) {
+	plain(t)
+	today := Today().Format("2006-01-02")
+
+	tests := []struct {
+		t    task.Task
+		want string
+	}{
+		{task.Task{ID: 3, Title: "Report", Due: today, Status: task.StatusTodo}, "     3  Report    Today"},
+		{task.Task{ID: 12, Title: "Review", Priority: true, Status: task.StatusTodo}, "  ! 12  Review    None"},
+		{task.Task{ID: 4, Title: "Deploy", Due: "2030-01-02", Status: task.StatusDoing, Tags: []string{"ops", "q3"}},
+			"     4  Deploy    2030-01-02  " + formatStatus(task.StatusDoing) + "  #ops  #q3"},
+	}
+	for _, tt := range tests {
+		if got := formatAgendaLine(tt.t, 2, 8); got != tt.want {
+			t.Errorf("formatAgendaLine(%s) = %q, want %q", tt.t.Title, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/muesli/termenv"
+	"github.com/tm-craggs/tidytask/task"
+)
+
+// CalendarOptions controls what PrintCalendar shows on each day
+type CalendarOptions struct {
+	Titles     bool // list the title of each task, rather than the number due
+	TitleWidth int  // cut titles to this many columns, 0 for no limit
+}
+
+// PrintCalendar draws the days from first to last as a grid with a column for each weekday, Monday to Sunday,
+// showing the tasks due on each day. days before first and after last in the same weeks are left blank.
+// days with overdue tasks are coloured red, today is marked, and completed tasks are counted separately in green
+func PrintCalendar(first, last time.Time, tasks []task.Task, opts CalendarOptions) error {
+	const layout = "2006-01-02"
+	today := Today().Format(layout)
+
+	// group tasks by due date, in the order given
+	due := make(map[string][]task.Task)
+	for _, t := range tasks {
+		due[t.Due] = append(due[t.Due], t)
+	}
+
+	header := []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+	var rows [][]string
+	for week := StartOfWeek(first); !week.After(last); week = week.AddDate(0, 0, 7) {
+		row := make([]string, 7)
+		for i := range row {
+			day := week.AddDate(0, 0, i)
+			if day.Before(first) || day.After(last) {
+				continue
+			}
+			row[i] = formatDay(day.Format(layout), today, due[day.Format(layout)], opts)
+		}
+		rows = append(rows, row)
+	}
+
+	// separate the weeks, as days can take several lines
+	return printTable(header, rows, true)
+}
+
+// formatDay returns the text of one day in the calendar, its day of the month followed by its tasks
+func formatDay(date, today string, tasks []task.Task, opts CalendarOptions) string {
+	label := strings.TrimPrefix(date[8:], "0")
+	if date == today {
+		label = colorise(label+" today", orange)
+	}
+	lines := []string{label}
+
+	// count open and completed tasks
+	open, done := 0, 0
+	for _, t := range tasks {
+		if t.Complete {
+			done++
+		} else {
+			open++
+		}
+	}
+
+	// open tasks due before today are overdue, and coloured red
+	var c termenv.Color
+	if open > 0 && date < today {
+		c = red
+	}
+
+	if opts.Titles {
+		for _, t := range tasks {
+			title := t.Title
+			if opts.TitleWidth > 0 {
+				title = runewidth.Truncate(title, opts.TitleWidth, "…")
+			}
+			if t.Complete {
+				lines = append(lines, colorise("✓ "+title, green))
+			} else {
+				lines = append(lines, colorise("• "+title, c))
+			}
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	if open > 0 {
+		lines = append(lines, colorise(fmt.Sprintf("%d due", open), c))
+	}
+	if done > 0 {
+		lines = append(lines, colorise(fmt.Sprintf("%d done", done), green))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,60 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/muesli/termenv"
+	"github.com/tm-craggs/tidytask/task"
+)
+
+// marked shows colours as text until the test ends: red text as <r>text</r>, green as <g>text</g>, orange as
+// <o>text</o>. tests have no terminal, so the colours are replaced with distinct ones first
+func marked(t *testing.T) {
+	t.Helper()
+	oldColorise, oldRed, oldGreen, oldOrange := colorise, red, green, orange
+	red, green, orange = termenv.ANSIRed, termenv.ANSIGreen, termenv.ANSIYellow
+	colorise = func(s string, c termenv.Color) string {
+		switch c {
+		case red:
+			return "<r>" + s + "</r>"
+		case green:
+			return "<g>" + s + "</g>"
+		case orange:
+			return "<o>" + s + "</o>"
+		default:
+			return s
+		}
+	}
+	t.Cleanup(func() { colorise, red, green, orange = oldColorise, oldRed, oldGreen, oldOrange })
+}
+
+func TestFormatDay(t *testing.T) {
+	marked(t)
+	const today = "2025-06-10"
+	open := task.Task{Title: "Write the quarterly report", Status: task.StatusTodo}
+	done := task.Task{Title: "Send invoice", Status: task.StatusDone, Complete: true}
+
+	tests := []struct {
+		name  string
+		date  string
+		tasks []task.Task
+		opts  CalendarOptions
+		want  string
+	}{
+		{"empty", "2025-06-01", nil, CalendarOptions{}, "1"},
+		{"today", today, nil, CalendarOptions{}, "<o>10 today</o>"},
+		{"due", "2025-06-12", []task.Task{open, open}, CalendarOptions{}, "12\n2 due"},
+		{"overdue", "2025-06-09", []task.Task{open}, CalendarOptions{}, "9\n<r>1 due</r>"},
+		{"done", "2025-06-09", []task.Task{done, open}, CalendarOptions{}, "9\n<r>1 due</r>\n<g>1 done</g>"},
+		{"only done is not overdue", "2025-06-09", []task.Task{done}, CalendarOptions{}, "9\n<g>1 done</g>"},
+		{"titles", "2025-06-12", []task.Task{open, done}, CalendarOptions{Titles: true},
+			"12\n• Write the quarterly report\n<g>✓ Send invoice</g>"},
+		{"cut titles", "2025-06-09", []task.Task{open}, CalendarOptions{Titles: true, TitleWidth: 8},
+			"9\n<r>• Write t…</r>"},
+	}
+	for _, tt := range tests {
+		if got := formatDay(tt.date, today, tt.tasks, tt.opts); got != tt.want {
+			t.Errorf("%s: formatDay = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package util
+
+import (
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/tw"
+)
+
+// PrintTable renders rows as a table in the terminal, in the same style as PrintTasks
+func PrintTable(header []string, rows [][]string) error {
+	return printTable(header, rows, false)
+}
+
+// printTable renders rows as a table, with a line between each row when rowLines is set
+func printTable(header []string, rows [][]string, rowLines bool) error {
+	table := tablewriter.NewWriter(os.Stdout)
+	if rowLines {
+		table.Options(tablewriter.WithRendition(tw.Rendition{
+			Settings: tw.Settings{Separators: tw.Separators{BetweenRows: tw.On}},
+		}))
+	}
+	table.Header(header)
+	if err := table.Bulk(rows); err != nil {
+		return err
+	}
+	return table.Render()
+}
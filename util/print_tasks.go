@@ -21,6 +21,7 @@ var (
 	brightBlue = p.Color("#35c5ff")
 	orange     = p.Color("#FF8000")
 	yellow     = p.Color("#D4D41E")
+	grey       = p.Color("#888888")
 
 	// ErrNoTasks is a custom error for when the input task list is empty
 	ErrNoTasks = errors.New("no tasks")
@@ -39,42 +40,79 @@ var (
 	}
 )
 
+// PrintOptions controls the optional columns shown by PrintTasks
+type PrintOptions struct {
+	Age       bool            // show how long ago each task was created
+	Handles   bool            // show the stable handle of each task next to its ID
+	Highlight []string        // words to highlight in task titles, such as search terms
+	Scores    map[int]float64 // match scores by task ID, shown as a percentage when set
+}
+
 // PrintTasks takes a slice of Task structs and displays them as a colour coded table in the terminal
-func PrintTasks(tasks []task.Task) error {
+func PrintTasks(tasks []task.Task, opts PrintOptions) error {
 	if len(tasks) == 0 {
 		return errors.Errorf("no tasks")
 	}
 
-	// create table and set up table headers
+	// create table and set up table headers, adding optional columns
 	table := tablewriter.NewWriter(os.Stdout)
-	table.Header([]string{"ID", "title", "due", "complete", "priority"})
+	header := []string{"ID"}
+	if opts.Handles {
+		header = append(header, "handle")
+	}
+	header = append(header, "title", "due", "status", "priority")
+
+	// only show tags when some task has them
+	showTags := false
+	for _, t := range tasks {
+		if len(t.Tags) > 0 {
+			showTags = true
+			break
+		}
+	}
+	if showTags {
+		header = append(header, "tags")
+	}
+	if opts.Age {
+		header = append(header, "age")
+	}
+	if opts.Scores != nil {
+		header = append(header, "score")
+	}
+	table.Header(header)
 
 	// iterate through all tasks in the slice
 	for _, t := range tasks {
 
-		// create empty strings for each column
-		var title, due, complete, priority string
-
-		if t.Complete {
-			// if task is complete, format task as complete, and colour priority green
-			// due will be formatted on whether it was on time, late, or early.
-			complete, title, due = formatCompletedTask(t)
-			priority = formatPriority(t.Priority, green, green)
-		} else {
-			// if task is incomplete, format as incomplete
-			complete, title, due = formatIncompleteTask(t)
-			// incomplete tasks use brightBlue for priority, no color for normal
-			priority = formatPriority(t.Priority, brightBlue, nil)
+		// mark search matches in the title before it is coloured
+		t.Title = highlightTerms(t.Title, opts.Highlight)
+
+		// style each column
+		title, due, status, priority := FormatTask(t)
+
+		// format the task data as a row
+		row := []string{fmt.Sprintf("%d", t.ID)} // task ID as string
+		if opts.Handles {
+			row = append(row, t.Handle)
+		}
+		row = append(row,
+			title,    // coloured task title
+			due,      // stylised due date
+			status,   // status symbol and name with colour
+			priority, // high or normal with colour
+		)
+		if showTags {
+			row = append(row, strings.Join(t.Tags, ", "))
+		}
+		if opts.Age {
+			row = append(row, formatAge(t.CreatedAt))
+		}
+		if opts.Scores != nil {
+			row = append(row, fmt.Sprintf("%.0f%%", opts.Scores[t.ID]*100))
 		}
 
-		// append the formatted task data as a row in the table
-		if err := table.Append([]string{
-			fmt.Sprintf("%d", t.ID), // task ID as string
-			title,                   // coloured task title
-			due,                     // stylised due date
-			complete,                // tick or cross with colour
-			priority,                // high or normal with colour
-		}); err != nil {
+		// append the row to the table
+		if err := table.Append(row); err != nil {
 			// if appending fails, log and move to next task
 			log.Printf("Error: Failed to append task ID %d to table: %v", t.ID, err)
 			continue
@@ -89,59 +127,96 @@ func PrintTasks(tasks []task.Task) error {
 	return nil
 }
 
+// FormatTask returns the title, due date, status and priority of a task, styled and colour coded as in PrintTasks
+func FormatTask(t task.Task) (title, due, status, priority string) {
+	switch {
+	case t.Complete:
+		// if task is complete, format task as complete, and colour priority green
+		// due will be formatted on whether it was on time, late, or early.
+		status, title, due = formatCompletedTask(t)
+		priority = formatPriority(t.Priority, green, green)
+	case t.Status == task.StatusCancelled:
+		// if task is cancelled, grey out the whole row
+		status, title, due = formatCancelledTask(t)
+		priority = formatPriority(t.Priority, grey, grey)
+	default:
+		// if task is incomplete, format as incomplete
+		status, title, due = formatIncompleteTask(t)
+		// incomplete tasks use brightBlue for priority, no color for normal
+		priority = formatPriority(t.Priority, brightBlue, nil)
+	}
+	return title, due, status, priority
+}
+
 // formatCompletedTask returns styled fields for a completed task
 func formatCompletedTask(t task.Task) (string, string, string) {
 
-	// colour green tick for complete field
-	complete := colorise("✔", green)
+	// colour green tick for status field
+	complete := formatStatus(t.Status)
 
 	// colour title field green
 	title := colorise(t.Title, green)
 
+	// due will be formatted on whether it was on time, late, or early
+	return complete, title, colorise(CompletionOutcome(t), green)
+}
+
+// CompletionOutcome describes whether a completed task met its due date, such as "Met: On Time",
+// "Met: 2 days early" or "Missed: 1 day late". the raw due date is returned if either date is invalid
+func CompletionOutcome(t task.Task) string {
+
 	// if due date empty, display "Met: No due"
 	if t.Due == "" {
-		return complete, title, colorise("Met: No due", green)
+		return "Met: No due"
 	}
 
-	// get current date
-	dueDate, err1 := time.Parse("2006-01-02", t.Due)
-
-	// get complete date
-	completeDate, err2 := time.Parse("2006-01-02", t.CompleteDate.String)
-
-	// if either parsing fails, display raw due date without being relative.
-	if err1 != nil || err2 != nil {
-		return complete, title, colorise(t.Due, green)
+	// if either date is invalid, display raw due date without being relative
+	diff, ok := DaysLate(t)
+	if !ok {
+		return t.Due
 	}
 
-	// calculate the difference in full days between due date and completion date
-	diff := int(truncateTime(completeDate).Sub(truncateTime(dueDate)).Hours() / 24)
-
 	// get a human-readable difference between the dates
+	dueDate, _ := time.Parse("2006-01-02", t.Due)
+	completeDate, _ := time.Parse("2006-01-02", t.CompleteDate.String)
 	diffText := dateDiff(dueDate, completeDate)
 
 	// format the due based on difference between due and submitted
-	var due string
 	switch {
 	case diff == 0:
-		due = "Met: On Time"
+		return "Met: On Time"
 	case diff < 0:
-		due = fmt.Sprintf("Met: %s early", diffText)
+		return fmt.Sprintf("Met: %s early", diffText)
 	default:
-		due = fmt.Sprintf("Missed: %s late", diffText)
+		return fmt.Sprintf("Missed: %s late", diffText)
+	}
+}
+
+// DaysLate returns the number of full days between a completed task's due date and completion date,
+// negative if it was completed early. ok is false if the task has no due date or either date is invalid
+func DaysLate(t task.Task) (days int, ok bool) {
+
+	// get due date
+	dueDate, err1 := time.Parse("2006-01-02", t.Due)
+
+	// get complete date
+	completeDate, err2 := time.Parse("2006-01-02", t.CompleteDate.String)
+
+	if err1 != nil || err2 != nil {
+		return 0, false
 	}
 
-	// return 3 formatted fields
-	return complete, title, colorise(due, green)
+	// calculate the difference in full days between due date and completion date
+	return int(truncateTime(completeDate).Sub(truncateTime(dueDate)).Hours() / 24), true
 }
 
 func formatIncompleteTask(t task.Task) (string, string, string) {
 
-	// colour red cross for complete field
-	complete := colorise("✘", red)
+	// colour symbol for status field, a red cross for todo
+	complete := formatStatus(t.Status)
 
 	// get a relative due date
-	relativeDue := formatDeadline(t.Due)
+	relativeDue := FormatDeadline(t.Due)
 
 	// if task overdue, colour complete, title and due as red
 	if strings.HasPrefix(relativeDue, "Overdue") {
@@ -159,6 +234,15 @@ func formatIncompleteTask(t task.Task) (string, string, string) {
 	}
 }
 
+// formatCancelledTask returns styled fields for a cancelled task, all in grey
+func formatCancelledTask(t task.Task) (string, string, string) {
+	due := t.Due
+	if due == "" {
+		due = "None"
+	}
+	return formatStatus(t.Status), colorise(t.Title, grey), colorise(due, grey)
+}
+
 // formatPriority returns a styled string representing the task's priority level.
 func formatPriority(isHigh bool, highColor, normalColor termenv.Color) string {
 	// if the task is high priority, return "High" styled with the highColor.
@@ -170,10 +254,27 @@ func formatPriority(isHigh bool, highColor, normalColor termenv.Color) string {
 	return colorise("Normal", normalColor)
 }
 
-// formatDeadline formats a due date string into a human-readable status.
+// formatAge returns how long ago a task was created, in days.
+// it returns "Today" for tasks created today, and "Unknown" if the creation time is not known.
+func formatAge(created time.Time) string {
+	if created.IsZero() {
+		return "Unknown"
+	}
+
+	// compare dates in local time, ignoring the time of day
+	created = created.Local()
+	today := time.Now()
+	if created.Format("2006-01-02") == today.Format("2006-01-02") {
+		return "Today"
+	}
+
+	return dateDiff(created, today)
+}
+
+// FormatDeadline formats a due date string into a human-readable status, as shown for open tasks.
 // it returns "None", "Today", "Tomorrow", a weekday name, or an ISO date.
 // if the date is past, it returns an "Overdue" label with how long it's overdue.
-func formatDeadline(due string) string {
+func FormatDeadline(due string) string {
 
 	// if there is no due date, return "None"
 	if due == "" {
@@ -188,14 +289,11 @@ func formatDeadline(due string) string {
 		return "Invalid date"
 	}
 
-	// get today's date, truncated to remove time
-	today := time.Now().Truncate(24 * time.Hour)
-
-	// truncate parsedDue to remove time
-	parsedDue = parsedDue.Truncate(24 * time.Hour)
+	// get today's local date
+	today := Today()
 
 	// calculate the difference in days between today's date and the due date
-	days := int(parsedDue.Sub(today).Hours() / 24)
+	days := DaysBetween(today, parsedDue)
 
 	// show overdue, today, or tomorrow, or day of the week when task is within a week.
 	// otherwise, show raw date
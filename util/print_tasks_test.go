@@ -0,0 +1,34 @@
+package util
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/tm-craggs/tidytask/task"
+)
+
+func TestCompletionOutcome(t *testing.T) {
+	tests := []struct {
+		due, completed string
+		days           int
+		ok             bool
+		want           string
+	}{
+		{"2025-03-10", "2025-03-10", 0, true, "Met: On Time"},
+		{"2025-03-10", "2025-03-09", -1, true, "Met: 1 day early"},
+		{"2025-03-10", "2025-03-01", -9, true, "Met: 9 days early"},
+		{"2025-03-10", "2025-03-12", 2, true, "Missed: 2 days late"},
+		{"2025-03-30", "2025-04-01", 2, true, "Missed: 2 days late"},
+		{"", "2025-03-10", 0, false, "Met: No due"},
+		{"soon", "2025-03-10", 0, false, "soon"},
+	}
+	for _, tt := range tests {
+		tk := task.Task{Due: tt.due, Complete: true, CompleteDate: sql.NullString{String: tt.completed, Valid: true}}
+		if days, ok := DaysLate(tk); days != tt.days || ok != tt.ok {
+			t.Errorf("DaysLate(due %q, completed %s) = %d, %v; want %d, %v", tt.due, tt.completed, days, ok, tt.days, tt.ok)
+		}
+		if got := CompletionOutcome(tk); got != tt.want {
+			t.Errorf("CompletionOutcome(due %q, completed %s) = %q, want %q", tt.due, tt.completed, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package util
+
+import (
+	"github.com/muesli/termenv"
+	"github.com/tm-craggs/tidytask/task"
+)
+
+// statusStyle is the symbol and colour used to display a task status
+type statusStyle struct {
+	symbol string
+	color  termenv.Color
+}
+
+// statusStyles holds the style of each known status, user-defined statuses are added by RegisterStatus
+var statusStyles = map[string]statusStyle{
+	task.StatusTodo:      {"✘", red},
+	task.StatusDoing:     {"▶", brightBlue},
+	task.StatusBlocked:   {"■", orange},
+	task.StatusDone:      {"✔", green},
+	task.StatusCancelled: {"–", grey},
+}
+
+// RegisterStatus adds a user-defined status so it is displayed in the given hex colour, e.g. "#AA66FF".
+// an empty colour displays the status without colour
+func RegisterStatus(name, hexColor string) {
+	var color termenv.Color
+	if hexColor != "" {
+		color = p.Color(hexColor)
+	}
+	statusStyles[name] = statusStyle{symbol: "●", color: color}
+}
+
+// formatStatus returns the status symbol and name, coloured for the status
+func formatStatus(status string) string {
+	style, ok := statusStyles[status]
+	if !ok {
+		// status is no longer configured, show it plainly
+		return "● " + status
+	}
+	return colorise(style.symbol+" "+status, style.color)
+}
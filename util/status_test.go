@@ -0,0 +1,37 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/muesli/termenv"
+	"github.com/tm-craggs/tidytask/task"
+)
+
+// plain turns off colours until the test ends, so output can be compared as text
+func plain(t *testing.T) {
+	t.Helper()
+	old := colorise
+	colorise = func(s string, _ termenv.Color) string { return s }
+	t.Cleanup(func() { colorise = old })
+}
+
+func TestFormatStatus(t *testing.T) {
+	plain(t)
+	RegisterStatus("review", "#AA66FF")
+	t.Cleanup(func() { delete(statusStyles, "review") })
+
+	tests := map[string]string{
+		task.StatusTodo:      "✘ todo",
+		task.StatusDoing:     "▶ doing",
+		task.StatusBlocked:   "■ blocked",
+		task.StatusDone:      "✔ done",
+		task.StatusCancelled: "– cancelled",
+		"review":             "● review",
+		"removed":            "● removed", // no longer in the config
+	}
+	for status, want := range tests {
+		if got := formatStatus(status); got != want {
+			t.Errorf("formatStatus(%q) = %q, want %q", status, got, want)
+		}
+	}
+}
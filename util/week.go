@@ -0,0 +1,12 @@
+package util
+
+import "time"
+
+// StartOfWeek returns the Monday of the week containing day, at the same time of day. weeks run from Monday
+// to Sunday
+func StartOfWeek(day time.Time) time.Time {
+
+	// days since Monday, Go weeks start on Sunday
+	weekday := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -weekday)
+}
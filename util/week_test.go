@@ -0,0 +1,20 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStartOfWeek(t *testing.T) {
+	for day, want := range map[string]string{
+		"2025-03-10": "2025-03-10", // Monday
+		"2025-03-12": "2025-03-10",
+		"2025-03-16": "2025-03-10", // Sunday
+		"2025-01-01": "2024-12-30", // across the year
+	} {
+		d, _ := time.ParseInLocation("2006-01-02", day, time.Local)
+		if got := StartOfWeek(d).Format("2006-01-02"); got != want {
+			t.Errorf("StartOfWeek(%s) = %s, want %s", day, got, want)
+		}
+	}
+}